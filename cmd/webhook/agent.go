@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	"github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/inventory"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilwait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// agentCommand is the subcommand running the node network inventory agent
+const agentCommand = "agent"
+
+// runAgent publishes the network inventory of the node it runs on, given the
+// arguments following the agent subcommand
+func runAgent(args []string) {
+	flags := flag.NewFlagSet(agentCommand, flag.ExitOnError)
+	// keep the logging flags of the webhook
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		flags.Var(f.Value, f.Name, f.Usage)
+	})
+	nodeName := flags.String("node-name", os.Getenv("NODE_NAME"), "Name of the node the agent runs on.")
+	cniBinDir := flags.String("cni-bin-dir", "/host/opt/cni/bin", "CNI bin directory of the node, as mounted in the agent pod.")
+	cniConfDirs := flags.String("cni-conf-dirs", "/host/etc/cni/multus/net.d", "Comma separated CNI config directories of the node, as mounted in the agent pod, in which Multus looks up the config of net-attach-defs without spec.config.")
+	interval := flags.Duration("interval", 5*time.Minute, "Interval at which the network inventory of the node is refreshed.")
+	flags.Parse(args)
+	// the logging flags are set, glog only checks the command line was parsed
+	flag.CommandLine.Parse(nil)
+
+	if *nodeName == "" {
+		glog.Fatal("the node name must be set with -node-name or the NODE_NAME environment variable")
+	}
+	var confDirs []string
+	for _, dir := range strings.Split(*cniConfDirs, ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			confDirs = append(confDirs, dir)
+		}
+	}
+
+	glog.Infof("starting net-attach-def-admission-controller inventory agent on node %s", *nodeName)
+
+	config, err := clientcmd.BuildConfigFromFlags("", os.Getenv("KUBECONFIG"))
+	if err != nil {
+		glog.Fatal(err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	admissionClient, err := admissionv1alpha1.NewRESTClient(config)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), *nodeName, metav1.GetOptions{})
+	if err != nil {
+		glog.Fatalf("error getting node %s: %v", *nodeName, err)
+	}
+
+	inventory.Run(admissionClient, node, *cniBinDir, confDirs, *interval, utilwait.NeverStop)
+}
@@ -44,6 +44,11 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == agentCommand {
+		runAgent(os.Args[2:])
+		return
+	}
+
 	// load configuration
 	port := flag.Int("port", 443, "The port on which to serve.")
 	address := flag.String("bind-address", "0.0.0.0", "The IP address on which to listen for the --port port.")
@@ -51,6 +56,25 @@ func main() {
 	cert := flag.String("tls-cert-file", "cert.pem", "File containing the default x509 Certificate for HTTPS.")
 	key := flag.String("tls-private-key-file", "key.pem", "File containing the default x509 private key matching --tls-cert-file.")
 	ignoreNamespaces := flag.String("ignore-namespaces", "", "Comma separated namespace list to ignore pod update")
+	subnetOverlapPolicy := flag.String("subnet-overlap-policy", webhook.PolicyDeny, "How to handle net-attach-defs whose IPAM ranges overlap another net-attach-def on the same L2 domain: deny, warn or ignore.")
+	defaultCNIVersion := flag.String("default-cni-version", webhook.DefaultCNIVersion, "cniVersion set by the mutating webhook on net-attach-defs that do not set one, empty to disable.")
+	missingNetworkPolicy := flag.String("missing-network-policy", webhook.PolicyDeny, "How to handle pods referencing net-attach-defs that do not exist: deny, warn or ignore.")
+	capabilityPolicy := flag.String("capability-policy", webhook.PolicyDeny, "How to handle pods requesting runtime capabilities (ips, mac, portMappings, bandwidth, infinibandGUID) that no plugin of the net-attach-def supports: deny, warn or ignore.")
+	staticIPPolicy := flag.String("static-ip-policy", webhook.PolicyDeny, "How to handle pods requesting static IPs outside the net-attach-def subnets, inside its dynamic allocation ranges or used by another running pod: deny, warn or ignore.")
+	identityFields := flag.String("identity-fields", webhook.DefaultIdentityFields, "Comma separated plugin fields, as type.field with dotted paths for nested fields, that may not change when a net-attach-def is updated.")
+	tuningSysctls := flag.String("tuning-sysctls", webhook.DefaultTuningSysctls, "Comma separated sysctls the tuning plugin may set, IFNAME standing for the name of any interface.")
+	cniBinDir := flag.String("cni-bin-dir", "", "Host CNI bin directory mounted in the webhook pod, in which the plugin binaries of net-attach-defs are run with CNI_COMMAND=VERSION, empty to disable.")
+	pluginBinaryPolicy := flag.String("plugin-binary-policy", webhook.PolicyDeny, "How to handle net-attach-defs whose plugin binaries are not installed in -cni-bin-dir or do not support their cniVersion: deny, warn or ignore.")
+	inventoryAgents := flag.String("inventory-agents", webhook.DefaultInventoryAgents, "Comma separated service accounts, as namespace/name, of the inventory agent, which may only write the network inventory of the node their pod runs on.")
+	inventoryPolicy := flag.String("inventory-policy", webhook.PolicyWarn, "How to handle net-attach-defs not matching the network inventory published by the agents of the nodes: deny, warn or ignore.")
+	lintSeverities := flag.String("lint-severities", "", "Comma separated rule=severity overrides of the net-attach-def lint rules, severity being error, warning or off.")
+	overlayPluginTypes := flag.String("overlay-plugin-types", webhook.DefaultOverlayPluginTypes, "Comma separated overlay plugin types, whose net-attach-defs the overlay-missing-mtu lint rule checks for an mtu.")
+	validateMode := flag.String("validate-mode", webhook.ModeEnforce, "Cluster-wide mode of the net-attach-def validation: enforce, warn or audit. Overridable per namespace with the k8s.v1.cni.cncf.io/validate-mode label.")
+	defaultNetworkOverride := flag.String("default-network-override", webhook.DefaultNetworkOverrideAllow, "Whether pods may replace the cluster default network with the v1.multus-cni.io/default-network annotation: allow or deny. Overridable per namespace with the k8s.v1.cni.cncf.io/default-network-override label.")
+	authorizePodServiceAccount := flag.Bool("authorize-pod-service-account", false, "Also allow pods to use net-attach-defs of other namespaces that RBAC lets the service account of the pod use, besides the user creating the pod.")
+	multusNamespace := flag.String("multus-namespace", webhook.DefaultMultusNamespace, "multusNamespace of the Multus deployment, in which the net-attach-defs of default-network annotations without a namespace are looked up.")
+	networkStatusWriters := flag.String("network-status-writers", webhook.DefaultNetworkStatusWriters, "Comma separated service accounts, as namespace/name, allowed to write the network-status annotations of pods.")
+	isolateMode := flag.String("isolate-mode", webhook.ModeEnforce, "Cluster-wide mode of the pod network annotation validation: enforce, warn or audit. Overridable per namespace with the k8s.v1.cni.cncf.io/isolate-mode label.")
 	flag.Parse()
 
 	glog.Infof("starting net-attach-def-admission-controller webhook server")
@@ -68,13 +92,71 @@ func main() {
 	// Register metrics
 	prometheus.MustRegister(localmetrics.NetAttachDefInstanceCounter)
 	prometheus.MustRegister(localmetrics.NetAttachDefEnabledInstanceUp)
+	prometheus.MustRegister(localmetrics.AdmissionViolationCounter)
 
 	// Including these stats kills performance when Prometheus polls with multiple targets
 	prometheus.Unregister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 	prometheus.Unregister(prometheus.NewGoCollector())
 
+	if err := webhook.SetSubnetOverlapPolicy(*subnetOverlapPolicy); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetDefaultCNIVersion(*defaultCNIVersion); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetMissingNetworkPolicy(*missingNetworkPolicy); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetCapabilityPolicy(*capabilityPolicy); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetStaticIPPolicy(*staticIPPolicy); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetIdentityFields(*identityFields); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetCNIBinDir(*cniBinDir); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetPluginBinaryPolicy(*pluginBinaryPolicy); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetInventoryAgents(*inventoryAgents); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetInventoryPolicy(*inventoryPolicy); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetLintSeverities(*lintSeverities); err != nil {
+		glog.Fatal(err)
+	}
+	webhook.SetOverlayPluginTypes(*overlayPluginTypes)
+	if err := webhook.SetTuningSysctls(*tuningSysctls); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetRuleSetMode(webhook.RuleSetValidate, *validateMode); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetRuleSetMode(webhook.RuleSetIsolate, *isolateMode); err != nil {
+		glog.Fatal(err)
+	}
+	if err := webhook.SetDefaultNetworkOverride(*defaultNetworkOverride); err != nil {
+		glog.Fatal(err)
+	}
+	webhook.SetAuthorizePodServiceAccount(*authorizePodServiceAccount)
+	if err := webhook.SetMultusNamespace(*multusNamespace); err != nil {
+		glog.Fatal(err)
+	}
+	controller.SetMultusNamespace(*multusNamespace)
+	if err := webhook.SetNetworkStatusWriters(*networkStatusWriters); err != nil {
+		glog.Fatal(err)
+	}
+
 	// init API client
 	webhook.SetupInClusterClient()
+	go webhook.StartInformers(utilwait.NeverStop)
+	webhook.SetNetworkPodLister(controller.PodsUsingNetwork)
 	// start metrics sever
 	startHTTPMetricServer(*metricsAddress)
 
@@ -86,8 +168,12 @@ func main() {
 		var httpServer *http.Server
 		http.HandleFunc("/validate", webhook.ValidateHandler)
 
+		http.HandleFunc("/mutate", webhook.MutateHandler)
+
 		http.HandleFunc("/isolate", webhook.IsolateHandler)
 
+		http.HandleFunc("/inventory", webhook.InventoryHandler)
+
 		// start serving
 		httpServer = &http.Server{
 			Addr: fmt.Sprintf("%s:%d", *address, *port),
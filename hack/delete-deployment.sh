@@ -45,13 +45,28 @@ cat ${BASE_DIR}/deployments/webhook-isolate.yaml | \
     sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
 	kubectl -n ${NAMESPACE} delete -f -
 
+cat ${BASE_DIR}/deployments/webhook-mutate.yaml | \
+	${BASE_DIR}/hack/webhook-patch-ca-bundle.sh | \
+    sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
+	kubectl -n ${NAMESPACE} delete -f -
+
+cat ${BASE_DIR}/deployments/webhook-inventory.yaml | \
+	${BASE_DIR}/hack/webhook-patch-ca-bundle.sh | \
+    sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
+	kubectl -n ${NAMESPACE} delete -f -
+
 cat ${BASE_DIR}/deployments/prometheus-roles.yaml | \
 	sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
     sed -e "s|\${PROMETHEUS_NAMESPACE}|${PROMETHEUS_NAMESPACE}|g" | \
 	kubectl -n ${NAMESPACE} delete -f -
 
+cat ${BASE_DIR}/deployments/agent.yaml | \
+    sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
+	kubectl -n ${NAMESPACE} delete -f -
+
 kubectl -n ${NAMESPACE} delete -f ${BASE_DIR}/deployments/deployment.yaml
 kubectl -n ${NAMESPACE} delete -f ${BASE_DIR}/deployments/roles.yaml
+kubectl delete -f ${BASE_DIR}/deployments/crds.yaml
 
 
 
@@ -10,6 +10,8 @@ PROMETHEUS_NAMESPACE="monitoring"
 OPERATOR_NAMESPACE="operators"
 INSTALL_SELF_SIGNED_CERT=true
 ENABLE_ISOLATE_WEBHOOK=false
+ENABLE_MUTATE_WEBHOOK=false
+ENABLE_INVENTORY_AGENT=false
 
 # Give help text for parameters.
 function usage()
@@ -19,6 +21,8 @@ function usage()
     echo -e "\t--install-self-signed-cert=${INSTALL_SELF_SIGNED_CERT}"
     echo -e "\t--namespace=${NAMESPACE}"
     echo -e "\t--enable-isolate-webhook"
+    echo -e "\t--enable-mutate-webhook"
+    echo -e "\t--enable-inventory-agent"
 }
 # Parse parameters given as arguments to this script.
 while [ "$1" != "" ]; do
@@ -35,6 +39,12 @@ while [ "$1" != "" ]; do
         --enable-isolate-webhook)
             ENABLE_ISOLATE_WEBHOOK=true
 	    ;;
+        --enable-mutate-webhook)
+            ENABLE_MUTATE_WEBHOOK=true
+	    ;;
+        --enable-inventory-agent)
+            ENABLE_INVENTORY_AGENT=true
+	    ;;
         --namespace)
             NAMESPACE=$VALUE
             ;;
@@ -51,10 +61,19 @@ if [ "${INSTALL_SELF_SIGNED_CERT}" == true ]; then
 	${BASE_DIR}/hack/webhook-create-signed-cert.sh --namespace ${NAMESPACE}
 fi
 
+kubectl create -f ${BASE_DIR}/deployments/crds.yaml
 kubectl -n ${NAMESPACE} create -f ${BASE_DIR}/deployments/roles.yaml
 kubectl -n ${NAMESPACE} create -f ${BASE_DIR}/deployments/deployment.yaml
 
 kubectl -n ${NAMESPACE} create -f ${BASE_DIR}/deployments/service.yaml
+
+# install node network inventory agent
+if [ "${ENABLE_INVENTORY_AGENT}" == true ]; then
+	cat ${BASE_DIR}/deployments/agent.yaml | \
+		sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
+		kubectl -n ${NAMESPACE} create -f -
+fi
+
 export NAMESPACE
 # install validate webhook
 cat ${BASE_DIR}/deployments/webhook-validate.yaml | \
@@ -70,6 +89,22 @@ if [ "${ENABLE_ISOLATE_WEBHOOK}" == true ]; then
 		kubectl -n ${NAMESPACE} create -f -
 fi
 
+# install inventory webhook
+if [ "${ENABLE_INVENTORY_AGENT}" == true ]; then
+	cat ${BASE_DIR}/deployments/webhook-inventory.yaml | \
+		${BASE_DIR}/hack/webhook-patch-ca-bundle.sh | \
+		sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
+		kubectl -n ${NAMESPACE} create -f -
+fi
+
+# install mutate webhook
+if [ "${ENABLE_MUTATE_WEBHOOK}" == true ]; then
+	cat ${BASE_DIR}/deployments/webhook-mutate.yaml | \
+		${BASE_DIR}/hack/webhook-patch-ca-bundle.sh | \
+		sed -e "s|\${NAMESPACE}|${NAMESPACE}|g" | \
+		kubectl -n ${NAMESPACE} create -f -
+fi
+
 
 sleep 5
 if [[ "$(kubectl get pod -l k8s-app=prometheus-operator -n ${OPERATOR_NAMESPACE} | grep -o prometheus-operator)" == "prometheus-operator" ]]; then
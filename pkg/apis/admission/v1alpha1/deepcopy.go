@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies the receiver into out
+func (in *NetworkAttachmentGrant) DeepCopyInto(out *NetworkAttachmentGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *NetworkAttachmentGrant) DeepCopy() *NetworkAttachmentGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAttachmentGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *NetworkAttachmentGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *NetworkAttachmentGrantSpec) DeepCopyInto(out *NetworkAttachmentGrantSpec) {
+	*out = *in
+	if in.Networks != nil {
+		out.Networks = make([]NetworkReference, len(in.Networks))
+		copy(out.Networks, in.Networks)
+	}
+	if in.Namespaces != nil {
+		out.Namespaces = make([]string, len(in.Namespaces))
+		copy(out.Namespaces, in.Namespaces)
+	}
+	if in.NamespaceSelector != nil {
+		out.NamespaceSelector = in.NamespaceSelector.DeepCopy()
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *NetworkAttachmentGrantList) DeepCopyInto(out *NetworkAttachmentGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]NetworkAttachmentGrant, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *NetworkAttachmentGrantList) DeepCopy() *NetworkAttachmentGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkAttachmentGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *NetworkAttachmentGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *PluginTypePolicy) DeepCopyInto(out *PluginTypePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *PluginTypePolicy) DeepCopy() *PluginTypePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginTypePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *PluginTypePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *PluginTypePolicySpec) DeepCopyInto(out *PluginTypePolicySpec) {
+	*out = *in
+	if in.Match != nil {
+		out.Match = make([]FieldPredicate, len(in.Match))
+		for i := range in.Match {
+			in.Match[i].DeepCopyInto(&out.Match[i])
+		}
+	}
+	if in.Namespaces != nil {
+		out.Namespaces = make([]string, len(in.Namespaces))
+		copy(out.Namespaces, in.Namespaces)
+	}
+	if in.NamespaceSelector != nil {
+		out.NamespaceSelector = in.NamespaceSelector.DeepCopy()
+	}
+	if in.Users != nil {
+		out.Users = make([]string, len(in.Users))
+		copy(out.Users, in.Users)
+	}
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		copy(out.Groups, in.Groups)
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *FieldPredicate) DeepCopyInto(out *FieldPredicate) {
+	*out = *in
+	if in.Values != nil {
+		out.Values = make([]string, len(in.Values))
+		copy(out.Values, in.Values)
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *PluginTypePolicyList) DeepCopyInto(out *PluginTypePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]PluginTypePolicy, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *PluginTypePolicyList) DeepCopy() *PluginTypePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginTypePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *PluginTypePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *NodeNetworkInventory) DeepCopyInto(out *NodeNetworkInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *NodeNetworkInventory) DeepCopy() *NodeNetworkInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeNetworkInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *NodeNetworkInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *NodeNetworkInventorySpec) DeepCopyInto(out *NodeNetworkInventorySpec) {
+	*out = *in
+	if in.Interfaces != nil {
+		out.Interfaces = make([]NodeInterface, len(in.Interfaces))
+		copy(out.Interfaces, in.Interfaces)
+	}
+	if in.CNIBinaries != nil {
+		out.CNIBinaries = make([]string, len(in.CNIBinaries))
+		copy(out.CNIBinaries, in.CNIBinaries)
+	}
+	if in.CNIConfigs != nil {
+		out.CNIConfigs = make([]CNIConfigFile, len(in.CNIConfigs))
+		copy(out.CNIConfigs, in.CNIConfigs)
+	}
+}
+
+// DeepCopyInto copies the receiver into out
+func (in *NodeNetworkInventoryList) DeepCopyInto(out *NodeNetworkInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		out.Items = make([]NodeNetworkInventory, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of the receiver
+func (in *NodeNetworkInventoryList) DeepCopy() *NodeNetworkInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeNetworkInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object
+func (in *NodeNetworkInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
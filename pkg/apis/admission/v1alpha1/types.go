@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkAttachmentGrant is a cluster-scoped resource granting the pods of
+// some namespaces the use of net-attach-defs of other namespaces
+type NetworkAttachmentGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NetworkAttachmentGrantSpec `json:"spec"`
+}
+
+// NetworkAttachmentGrantSpec lists the granted net-attach-defs and the
+// namespaces they are granted to
+type NetworkAttachmentGrantSpec struct {
+	// Networks are the granted net-attach-defs
+	Networks []NetworkReference `json:"networks"`
+	// Namespaces are the names of the namespaces granted the networks
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces granted the networks, in
+	// addition to Namespaces
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// NetworkReference refers to a net-attach-def, or to every net-attach-def of
+// a namespace if Name is empty
+type NetworkReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name,omitempty"`
+}
+
+// NetworkAttachmentGrantList is a list of NetworkAttachmentGrants
+type NetworkAttachmentGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NetworkAttachmentGrant `json:"items"`
+}
+
+// PluginTypePolicy is a cluster-scoped resource restricting the creation of
+// net-attach-defs with a privileged CNI plugin type to some namespaces or
+// users
+type PluginTypePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PluginTypePolicySpec `json:"spec"`
+}
+
+// PluginTypePolicySpec lists the plugins the policy restricts and who may
+// create net-attach-defs with them
+type PluginTypePolicySpec struct {
+	// Type is the restricted CNI plugin type
+	Type string `json:"type"`
+	// Match restricts the policy to the plugins of the type whose fields
+	// match every predicate; the policy applies to every plugin of the type
+	// if empty
+	Match []FieldPredicate `json:"match,omitempty"`
+	// Namespaces are the names of the namespaces allowed to hold
+	// net-attach-defs with the plugins
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces allowed to hold
+	// net-attach-defs with the plugins, in addition to Namespaces
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Users are the users allowed to create net-attach-defs with the plugins
+	// in any namespace
+	Users []string `json:"users,omitempty"`
+	// Groups are the groups whose members are allowed to create
+	// net-attach-defs with the plugins in any namespace
+	Groups []string `json:"groups,omitempty"`
+}
+
+// FieldPredicate matches a field of a plugin config
+type FieldPredicate struct {
+	// Field is the dot separated path of the field in the plugin config
+	Field string `json:"field"`
+	// Values are the values matching the field, compared with their JSON
+	// form less the quotes of strings; an empty value matches a missing
+	// field
+	Values []string `json:"values"`
+}
+
+// PluginTypePolicyList is a list of PluginTypePolicies
+type PluginTypePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PluginTypePolicy `json:"items"`
+}
+
+// NodeNetworkInventory is a cluster-scoped resource, named after a node,
+// publishing the network interfaces, CNI plugin binaries and CNI config files
+// of the node, as found by the inventory agent running on it
+type NodeNetworkInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeNetworkInventorySpec `json:"spec"`
+}
+
+// NodeNetworkInventorySpec is the network inventory of a node
+type NodeNetworkInventorySpec struct {
+	// Interfaces are the network interfaces of the host network namespace
+	Interfaces []NodeInterface `json:"interfaces,omitempty"`
+	// CNIBinaries are the names of the plugin binaries of the CNI bin
+	// directory
+	CNIBinaries []string `json:"cniBinaries,omitempty"`
+	// CNIConfigs are the CNI config files of the node-local config
+	// directories
+	CNIConfigs []CNIConfigFile `json:"cniConfigs,omitempty"`
+}
+
+// NodeInterface is a network interface of a node
+type NodeInterface struct {
+	Name string `json:"name"`
+	MTU  int    `json:"mtu"`
+	// SRIOVTotalVFs is the number of SR-IOV virtual functions the interface
+	// supports, 0 if it is not SR-IOV capable
+	SRIOVTotalVFs int `json:"sriovTotalVFs,omitempty"`
+}
+
+// CNIConfigFile is a CNI config file of a node
+type CNIConfigFile struct {
+	// Path is the path of the file on the node
+	Path string `json:"path"`
+	// Name is the network name the file configures
+	Name string `json:"name"`
+}
+
+// NodeNetworkInventoryList is a list of NodeNetworkInventories
+type NodeNetworkInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeNetworkInventory `json:"items"`
+}
@@ -115,6 +115,7 @@ func StartWatching(ignoreNamespaces *string) {
 	)
 
 	c := newResourceController(clientset, nadClientset, informer)
+	setWatchingController(c)
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 	go c.Run(stopCh)
@@ -186,12 +187,16 @@ func newResourceController(client kubernetes.Interface, nadClient *netattachdefC
 		},
 	})
 
-	return &Controller{
+	c := &Controller{
 		clientset:    client,
 		nadClientset: nadClient,
 		informer:     informer,
 		queue:        queue,
 	}
+	if err := informer.AddIndexers(cache.Indexers{podNetworkIndex: c.podNetworkIndexFunc}); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to add pod network index: %v", err))
+	}
+	return c
 }
 
 // Run starts the kubewatch controller
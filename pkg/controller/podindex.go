@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"sync"
+
+	api_v1 "k8s.io/api/core/v1"
+)
+
+// podNetworkIndex indexes running pods by the namespace/name of every
+// net-attach-def their networks and default-network annotations refer to
+const podNetworkIndex = "network"
+
+// defaultNetworkPodAnnotation replaces the cluster default network of a pod
+const defaultNetworkPodAnnotation = "v1.multus-cni.io/default-network"
+
+// multusNamespace is the namespace Multus resolves default-network
+// annotations without a namespace against
+var multusNamespace = "kube-system"
+
+// SetMultusNamespace sets the namespace the net-attach-defs of default-network
+// annotations without a namespace are indexed under
+func SetMultusNamespace(namespace string) {
+	multusNamespace = namespace
+}
+
+var (
+	// watchingController is the controller started by StartWatching, read by
+	// the admission webhook through PodsUsingNetwork
+	watchingController     *Controller
+	watchingControllerLock sync.RWMutex
+)
+
+func setWatchingController(c *Controller) {
+	watchingControllerLock.Lock()
+	defer watchingControllerLock.Unlock()
+	watchingController = c
+}
+
+// podNetworkIndexFunc returns the net-attach-defs referenced by a pod
+func (c *Controller) podNetworkIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*api_v1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	var keys []string
+	seen := map[string]bool{}
+	defaultNamespaces := map[string]string{
+		nadPodAnnotation:            pod.Namespace,
+		defaultNetworkPodAnnotation: multusNamespace,
+	}
+	for _, annotation := range []string{nadPodAnnotation, defaultNetworkPodAnnotation} {
+		podNetworks, ok := pod.GetAnnotations()[annotation]
+		if !ok {
+			continue
+		}
+		networks, err := c.parsePodNetworkAnnotation(podNetworks, defaultNamespaces[annotation])
+		if err != nil {
+			// malformed annotations are not indexed rather than failing the store
+			continue
+		}
+		for _, network := range networks {
+			key := network.Namespace + "/" + network.Name
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// PodsUsingNetwork returns the running pods whose networks or default-network
+// annotation refers to the net-attach-def namespace/name. The second return value is false
+// while the pod cache is not started or not synced yet.
+func PodsUsingNetwork(namespace, name string) ([]*api_v1.Pod, bool, error) {
+	watchingControllerLock.RLock()
+	c := watchingController
+	watchingControllerLock.RUnlock()
+	if c == nil || !c.HasSynced() {
+		return nil, false, nil
+	}
+
+	objs, err := c.informer.GetIndexer().ByIndex(podNetworkIndex, namespace+"/"+name)
+	if err != nil {
+		return nil, true, err
+	}
+	pods := make([]*api_v1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		if pod, ok := obj.(*api_v1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	return pods, true, nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inventory collects the network inventory of a node and publishes
+// it as a NodeNetworkInventory
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/golang/glog"
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilwait "k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+)
+
+// Resource is the resource name of NodeNetworkInventories
+const Resource = "nodenetworkinventories"
+
+// cniConfigExtensions are the extensions of the CNI config files, as loaded
+// by Multus
+var cniConfigExtensions = []string{".conf", ".conflist", ".json"}
+
+var (
+	// sysClassNet is the sysfs directory of the network interfaces
+	sysClassNet   = "/sys/class/net"
+	netInterfaces = net.Interfaces
+)
+
+// Collect returns the network inventory of the node: the interfaces of the
+// host network namespace, the plugin binaries of cniBinDir and the config
+// files of cniConfDirs. Missing directories are reported as empty.
+func Collect(cniBinDir string, cniConfDirs []string) (admissionv1alpha1.NodeNetworkInventorySpec, error) {
+	spec := admissionv1alpha1.NodeNetworkInventorySpec{}
+
+	interfaces, err := netInterfaces()
+	if err != nil {
+		return spec, err
+	}
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		spec.Interfaces = append(spec.Interfaces, admissionv1alpha1.NodeInterface{
+			Name:          iface.Name,
+			MTU:           iface.MTU,
+			SRIOVTotalVFs: sriovTotalVFs(iface.Name),
+		})
+	}
+	sort.Slice(spec.Interfaces, func(i, j int) bool { return spec.Interfaces[i].Name < spec.Interfaces[j].Name })
+
+	if spec.CNIBinaries, err = cniBinaries(cniBinDir); err != nil {
+		return spec, err
+	}
+
+	for _, dir := range cniConfDirs {
+		configs, err := cniConfigs(dir)
+		if err != nil {
+			return spec, err
+		}
+		spec.CNIConfigs = append(spec.CNIConfigs, configs...)
+	}
+	return spec, nil
+}
+
+// sriovTotalVFs returns the number of virtual functions an interface
+// supports, 0 if it is not SR-IOV capable
+func sriovTotalVFs(name string) int {
+	data, err := ioutil.ReadFile(filepath.Join(sysClassNet, name, "device", "sriov_totalvfs"))
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// cniBinaries returns the names of the executables of a CNI bin directory
+func cniBinaries(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var binaries []string
+	for _, entry := range entries {
+		// follow symbolic links to the binaries
+		info, err := os.Stat(filepath.Join(dir, entry.Name()))
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		binaries = append(binaries, entry.Name())
+	}
+	return binaries, nil
+}
+
+// cniConfigs returns the CNI config files of a directory along with the
+// network names they configure
+func cniConfigs(dir string) ([]admissionv1alpha1.CNIConfigFile, error) {
+	files, err := libcni.ConfFiles(dir, cniConfigExtensions)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var configs []admissionv1alpha1.CNIConfigFile
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var conf struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(data, &conf); err != nil || conf.Name == "" {
+			glog.Warningf("skipping CNI config file %s without network name: %v", file, err)
+			continue
+		}
+		configs = append(configs, admissionv1alpha1.CNIConfigFile{Path: file, Name: conf.Name})
+	}
+	return configs, nil
+}
+
+// Publish creates or updates the NodeNetworkInventory of a node. The
+// inventory is owned by the node, so that it is deleted along with it.
+func Publish(client rest.Interface, node *v1.Node, spec admissionv1alpha1.NodeNetworkInventorySpec) error {
+	typeMeta := metav1.TypeMeta{APIVersion: admissionv1alpha1.SchemeGroupVersion.String(), Kind: "NodeNetworkInventory"}
+	inventory := &admissionv1alpha1.NodeNetworkInventory{}
+	err := client.Get().Resource(Resource).Name(node.Name).Do(context.TODO()).Into(inventory)
+	if apierrors.IsNotFound(err) {
+		inventory = &admissionv1alpha1.NodeNetworkInventory{
+			TypeMeta: typeMeta,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: node.Name,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "Node",
+					Name:       node.Name,
+					UID:        node.UID,
+				}},
+			},
+			Spec: spec,
+		}
+		glog.Infof("creating network inventory of node %s", node.Name)
+		return client.Post().Resource(Resource).Body(inventory).Do(context.TODO()).Error()
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(inventory.Spec, spec) {
+		return nil
+	}
+	inventory.TypeMeta = typeMeta
+	inventory.Spec = spec
+	glog.Infof("updating network inventory of node %s", node.Name)
+	return client.Put().Resource(Resource).Name(node.Name).Body(inventory).Do(context.TODO()).Error()
+}
+
+// Run collects and publishes the network inventory of a node every interval
+// until stopCh is closed
+func Run(client rest.Interface, node *v1.Node, cniBinDir string, cniConfDirs []string, interval time.Duration, stopCh <-chan struct{}) {
+	utilwait.Until(func() {
+		spec, err := Collect(cniBinDir, cniConfDirs)
+		if err != nil {
+			glog.Errorf("failed to collect the network inventory of node %s: %v", node.Name, err)
+			return
+		}
+		if err := Publish(client, node, spec); err != nil {
+			glog.Errorf("failed to publish the network inventory of node %s: %v", node.Name, err)
+		}
+	}, interval, stopCh)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+)
+
+// writeFile writes a file, creating its directory
+func writeFile(path, content string, perm os.FileMode) {
+	Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+	Expect(ioutil.WriteFile(path, []byte(content), perm)).To(Succeed())
+}
+
+// fakeInventoryServer serves the NodeNetworkInventories it stores, and counts
+// the requests by method
+type fakeInventoryServer struct {
+	inventories map[string][]byte
+	requests    map[string]int
+}
+
+func (s *fakeInventoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests[r.Method]++
+	name := filepath.Base(r.URL.Path)
+	w.Header().Set("Content-Type", "application/json")
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := s.inventories[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(metav1.Status{
+				TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+				Status:   metav1.StatusFailure,
+				Reason:   metav1.StatusReasonNotFound,
+				Code:     http.StatusNotFound,
+			})
+			return
+		}
+		w.Write(data)
+	case http.MethodPost, http.MethodPut:
+		data, _ := ioutil.ReadAll(r.Body)
+		var inv admissionv1alpha1.NodeNetworkInventory
+		Expect(json.Unmarshal(data, &inv)).To(Succeed())
+		s.inventories[inv.Name] = data
+		w.Write(data)
+	}
+}
+
+// stored returns the NodeNetworkInventory the server stores for a node
+func (s *fakeInventoryServer) stored(name string) *admissionv1alpha1.NodeNetworkInventory {
+	inv := &admissionv1alpha1.NodeNetworkInventory{}
+	Expect(json.Unmarshal(s.inventories[name], inv)).To(Succeed())
+	return inv
+}
+
+var _ = Describe("Node network inventory", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "inventory")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		sysClassNet = "/sys/class/net"
+		netInterfaces = net.Interfaces
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should collect the interfaces, binaries and config files of the node", func() {
+		netInterfaces = func() ([]net.Interface, error) {
+			return []net.Interface{
+				{Name: "lo", MTU: 65536, Flags: net.FlagUp | net.FlagLoopback},
+				{Name: "eth1", MTU: 9000, Flags: net.FlagUp},
+				{Name: "eth0", MTU: 1500, Flags: net.FlagUp},
+			}, nil
+		}
+		sysClassNet = filepath.Join(dir, "sys")
+		writeFile(filepath.Join(sysClassNet, "eth1", "device", "sriov_totalvfs"), "64\n", 0644)
+
+		binDir := filepath.Join(dir, "bin")
+		writeFile(filepath.Join(binDir, "macvlan"), "#!/bin/sh\n", 0755)
+		writeFile(filepath.Join(binDir, "bridge"), "#!/bin/sh\n", 0755)
+		writeFile(filepath.Join(binDir, "README"), "not a plugin\n", 0644)
+		Expect(os.Symlink(filepath.Join(binDir, "macvlan"), filepath.Join(binDir, "macvlan-link"))).To(Succeed())
+
+		confDir := filepath.Join(dir, "net.d")
+		writeFile(filepath.Join(confDir, "10-storage.conflist"), `{"name": "storage", "plugins": [{"type": "macvlan"}]}`, 0644)
+		writeFile(filepath.Join(confDir, "00-legacy.conf"), `{"name": "legacy", "type": "bridge"}`, 0644)
+		writeFile(filepath.Join(confDir, "broken.json"), `{`, 0644)
+		writeFile(filepath.Join(confDir, "notes.txt"), `{"name": "notes"}`, 0644)
+
+		spec, err := Collect(binDir, []string{confDir, filepath.Join(dir, "missing")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(admissionv1alpha1.NodeNetworkInventorySpec{
+			Interfaces: []admissionv1alpha1.NodeInterface{
+				{Name: "eth0", MTU: 1500},
+				{Name: "eth1", MTU: 9000, SRIOVTotalVFs: 64},
+			},
+			CNIBinaries: []string{"bridge", "macvlan", "macvlan-link"},
+			CNIConfigs: []admissionv1alpha1.CNIConfigFile{
+				{Path: filepath.Join(confDir, "00-legacy.conf"), Name: "legacy"},
+				{Path: filepath.Join(confDir, "10-storage.conflist"), Name: "storage"},
+			},
+		}))
+	})
+
+	It("should report missing directories as empty", func() {
+		netInterfaces = func() ([]net.Interface, error) { return nil, nil }
+		spec, err := Collect(filepath.Join(dir, "bin"), []string{filepath.Join(dir, "net.d")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spec).To(Equal(admissionv1alpha1.NodeNetworkInventorySpec{}))
+	})
+
+	It("should create, update and keep the inventory of the node", func() {
+		server := &fakeInventoryServer{inventories: map[string][]byte{}, requests: map[string]int{}}
+		ts := httptest.NewServer(server)
+		defer ts.Close()
+		client, err := admissionv1alpha1.NewRESTClient(&rest.Config{Host: ts.URL})
+		Expect(err).NotTo(HaveOccurred())
+		node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: types.UID("1234")}}
+		spec := admissionv1alpha1.NodeNetworkInventorySpec{
+			Interfaces: []admissionv1alpha1.NodeInterface{{Name: "eth1", MTU: 1500}},
+		}
+
+		Expect(Publish(client, node, spec)).To(Succeed())
+		Expect(server.requests[http.MethodPost]).To(Equal(1))
+		inv := server.stored("node1")
+		Expect(inv.Kind).To(Equal("NodeNetworkInventory"))
+		Expect(inv.OwnerReferences).To(ConsistOf(metav1.OwnerReference{APIVersion: "v1", Kind: "Node", Name: "node1", UID: "1234"}))
+		Expect(inv.Spec).To(Equal(spec))
+
+		By("not updating an unchanged inventory")
+		Expect(Publish(client, node, spec)).To(Succeed())
+		Expect(server.requests[http.MethodPut]).To(Equal(0))
+
+		By("updating a changed inventory")
+		spec.Interfaces[0].MTU = 9000
+		Expect(Publish(client, node, spec)).To(Succeed())
+		Expect(server.requests[http.MethodPut]).To(Equal(1))
+		Expect(server.stored("node1").Spec).To(Equal(spec))
+		Expect(server.requests[http.MethodPost]).To(Equal(1))
+	})
+})
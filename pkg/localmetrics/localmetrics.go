@@ -43,6 +43,12 @@ var (
 			Name: "network_attachment_definition_enabled_instance_up",
 			Help: "Metric to identify clusters with network attachment definition enabled instances.",
 		}, []string{"networks"})
+	// AdmissionViolationCounter ... violations admitted because the rule set is in warn or audit mode
+	AdmissionViolationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "network_attachment_definition_admission_violations_total",
+			Help: "Metric to count requests violating a webhook rule set that were admitted because of its warn or audit mode.",
+		}, []string{"ruleset", "mode", "namespace"})
 )
 
 // UpdateNetAttachDefInstanceMetrics ...
@@ -83,6 +89,12 @@ func SetNetAttachDefEnabledInstanceUp(tp string, val int) {
 		"networks": tp}).Set(float64(val))
 }
 
+// IncAdmissionViolation ... count a violation admitted in warn or audit mode
+func IncAdmissionViolation(ruleSet string, mode string, namespace string) {
+	AdmissionViolationCounter.With(prometheus.Labels{
+		"ruleset": ruleSet, "mode": mode, "namespace": namespace}).Inc()
+}
+
 // InitMetrics ... empty metrics
 func InitMetrics() {
 	UpdateNetAttachDefInstanceMetrics("any", initialMetricsCount)
@@ -0,0 +1,251 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/containernetworking/cni/pkg/invoke"
+	"github.com/containernetworking/cni/pkg/version"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	// pluginProbeTimeout bounds the run of a plugin binary asked for its
+	// version
+	pluginProbeTimeout = 3 * time.Second
+	// pluginProbeBudget bounds the probing of all the plugin binaries of a
+	// NAD, well below the timeout of the webhook
+	pluginProbeBudget = 5 * time.Second
+)
+
+// binaryStamp identifies the content of a binary by its size and
+// modification time, along with its SHA-256
+type binaryStamp struct {
+	Size    int64
+	ModTime time.Time
+	Hash    string
+}
+
+var (
+	// cniBinDir is the CNI bin directory of a host the plugin binaries are
+	// probed in, empty to disable the probing
+	cniBinDir          = ""
+	pluginBinaryPolicy = PolicyDeny
+
+	// binaryStamps holds the stamp of the probed binaries by path, so that
+	// unchanged binaries are not hashed again
+	binaryStamps = map[string]binaryStamp{}
+	// pluginVersionCache holds the versions supported by the probed plugin
+	// binaries by the SHA-256 of the binary, so that updated binaries are
+	// probed again
+	pluginVersionCache = map[string]version.PluginInfo{}
+	// pluginProbeFailures holds the failed probes by the SHA-256 and
+	// modification time of the binary, so that a failing or hanging binary
+	// is only run again once it is replaced or touched
+	pluginProbeFailures    = map[string]error{}
+	pluginVersionCacheLock sync.Mutex
+)
+
+// SetCNIBinDir sets the host CNI bin directory, mounted in the webhook pod,
+// the plugin binaries of NADs are probed in. An empty directory disables the
+// probing.
+func SetCNIBinDir(dir string) error {
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return errors.Wrapf(err, "invalid CNI bin directory '%s'", dir)
+		}
+		if !info.IsDir() {
+			return errors.Errorf("invalid CNI bin directory '%s', not a directory", dir)
+		}
+	}
+	cniBinDir = dir
+	return nil
+}
+
+// SetPluginBinaryPolicy sets how NADs using plugins that are not installed in
+// the CNI bin directory, or do not support the version of the NAD, are
+// handled
+func SetPluginBinaryPolicy(policy string) error {
+	p, err := parsePolicy("plugin binary", policy)
+	if err != nil {
+		return err
+	}
+	pluginBinaryPolicy = p
+	return nil
+}
+
+// hashFile returns the SHA-256 of a file
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// binaryHash returns the SHA-256 of a binary and its modification time,
+// only reading binaries whose path, size or modification time changed since
+// they were last hashed
+func binaryHash(path string) (string, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	pluginVersionCacheLock.Lock()
+	stamp, ok := binaryStamps[path]
+	pluginVersionCacheLock.Unlock()
+	if ok && stamp.Size == info.Size() && stamp.ModTime.Equal(info.ModTime()) {
+		return stamp.Hash, stamp.ModTime, nil
+	}
+
+	hash, err := hashFile(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	pluginVersionCacheLock.Lock()
+	binaryStamps[path] = binaryStamp{Size: info.Size(), ModTime: info.ModTime(), Hash: hash}
+	pluginVersionCacheLock.Unlock()
+	return hash, info.ModTime(), nil
+}
+
+// probePluginVersions runs a plugin binary of the CNI bin directory with
+// CNI_COMMAND=VERSION and returns the versions it supports. The binary is
+// only run when no binary with the same content was probed before, and not
+// again after failing until it changes. The run is bounded by
+// pluginProbeTimeout and by the deadline of ctx; a run cut short by ctx is not
+// cached.
+func probePluginVersions(ctx context.Context, pluginType string) (version.PluginInfo, error) {
+	path, err := invoke.FindInPath(pluginType, []string{cniBinDir})
+	if err != nil {
+		return nil, err
+	}
+	hash, modTime, err := binaryHash(path)
+	if err != nil {
+		return nil, err
+	}
+	failureKey := fmt.Sprintf("%s@%d", hash, modTime.UnixNano())
+
+	pluginVersionCacheLock.Lock()
+	info, ok := pluginVersionCache[hash]
+	failure, failed := pluginProbeFailures[failureKey]
+	pluginVersionCacheLock.Unlock()
+	if ok {
+		return info, nil
+	}
+	if failed {
+		return nil, failure
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, pluginProbeTimeout)
+	defer cancel()
+	info, err = libcni.NewCNIConfig([]string{cniBinDir}, nil).GetVersionInfo(probeCtx, pluginType)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if probeCtx.Err() != nil {
+			err = errors.Errorf("did not report its versions within %s", pluginProbeTimeout)
+		}
+		glog.Warningf("plugin binary %s (sha256 %s) failed to report its versions: %v", path, hash, err)
+		pluginVersionCacheLock.Lock()
+		pluginProbeFailures[failureKey] = err
+		pluginVersionCacheLock.Unlock()
+		return nil, err
+	}
+	glog.Infof("plugin binary %s (sha256 %s) supports CNI versions %s", path, hash, strings.Join(info.SupportedVersions(), ", "))
+
+	pluginVersionCacheLock.Lock()
+	pluginVersionCache[hash] = info
+	pluginVersionCacheLock.Unlock()
+	return info, nil
+}
+
+// checkPluginBinaries verifies the binary of every plugin of a NAD config,
+// IPAM plugins included, is installed in the CNI bin directory and supports
+// the version of the config. Depending on the plugin binary policy the
+// failures are returned as an error or as warnings. Binaries left to probe
+// once pluginProbeBudget is spent are not checked.
+func checkPluginBinaries(confBytes []byte) ([]string, error) {
+	if cniBinDir == "" || pluginBinaryPolicy == PolicyIgnore {
+		return nil, nil
+	}
+	var conf struct {
+		CNIVersion  string   `json:"cniVersion"`
+		CNIVersions []string `json:"cniVersions"`
+	}
+	if err := json.Unmarshal(confBytes, &conf); err != nil {
+		return nil, nil
+	}
+	cniVersion := resolveCNIVersion(conf.CNIVersion, conf.CNIVersions)
+	plugins, err := getPluginConfs(confBytes)
+	if err != nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pluginProbeBudget)
+	defer cancel()
+	var failures []string
+	check := func(pluginType string, path *field.Path) {
+		if pluginType == "" {
+			return
+		}
+		info, err := probePluginVersions(ctx, pluginType)
+		if err != nil && ctx.Err() != nil {
+			glog.Warningf("skipping plugin binary check of %s: %s: probing exceeded %s", path, pluginType, pluginProbeBudget)
+			return
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s: %v", path, pluginType, err))
+			return
+		}
+		if cniVersion != "" && !containsString(info.SupportedVersions(), cniVersion) {
+			failures = append(failures, fmt.Sprintf("%s: %s supports CNI versions %s, not %s",
+				path, pluginType, strings.Join(info.SupportedVersions(), ", "), cniVersion))
+		}
+	}
+	for _, plugin := range plugins {
+		check(plugin.Type, plugin.Path.Child("type"))
+		if ipam, ok := plugin.Raw["ipam"].(map[string]interface{}); ok {
+			ipamType, _ := ipam["type"].(string)
+			check(ipamType, plugin.Path.Child("ipam", "type"))
+		}
+	}
+	if len(failures) == 0 {
+		return nil, nil
+	}
+
+	msg := fmt.Sprintf("plugin binaries in %s are missing or do not support the net-attach-def: %s", cniBinDir, strings.Join(failures, ", "))
+	glog.Info(msg)
+	return applyPolicy(pluginBinaryPolicy, msg)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/containernetworking/cni/pkg/version"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+// writeFakePlugin installs a plugin binary answering CNI_COMMAND=VERSION
+// with the given versions, and recording each of its runs in the calls file
+// of the directory
+func writeFakePlugin(dir, name string, versions ...string) {
+	script := fmt.Sprintf(`#!/bin/sh
+echo run >> "$(dirname "$0")/calls"
+echo '{"cniVersion": "%s", "supportedVersions": ["%s"]}'
+`, versions[len(versions)-1], strings.Join(versions, `", "`))
+	Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(script), 0755)).To(Succeed())
+}
+
+// writeHangingPlugin installs a plugin binary that records its run and never
+// answers
+func writeHangingPlugin(dir, name string) {
+	script := fmt.Sprintf(`#!/bin/sh
+echo run >> "$(dirname "$0")/calls"
+# %s
+exec sleep 30
+`, name)
+	Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(script), 0755)).To(Succeed())
+}
+
+// pluginRuns returns how many times the fake plugins of a directory were run
+func pluginRuns(dir string) int {
+	calls, err := ioutil.ReadFile(filepath.Join(dir, "calls"))
+	if os.IsNotExist(err) {
+		return 0
+	}
+	Expect(err).NotTo(HaveOccurred())
+	return strings.Count(string(calls), "run")
+}
+
+var _ = Describe("Plugin binary probing", func() {
+	var binDir string
+
+	BeforeEach(func() {
+		var err error
+		binDir, err = ioutil.TempDir("", "cni-bin")
+		Expect(err).NotTo(HaveOccurred())
+		writeFakePlugin(binDir, "macvlan", "0.3.1", "0.4.0", "1.0.0")
+		writeFakePlugin(binDir, "host-local", "0.3.1", "0.4.0", "1.0.0", "1.1.0")
+		writeFakePlugin(binDir, "tuning", "0.1.0", "0.2.0")
+		Expect(SetCNIBinDir(binDir)).To(Succeed())
+		pluginVersionCache = map[string]version.PluginInfo{}
+		pluginProbeFailures = map[string]error{}
+		binaryStamps = map[string]binaryStamp{}
+	})
+
+	AfterEach(func() {
+		pluginProbeTimeout = 3 * time.Second
+		pluginProbeBudget = 5 * time.Second
+		cniBinDir = ""
+		pluginBinaryPolicy = PolicyDeny
+		Expect(os.RemoveAll(binDir)).To(Succeed())
+	})
+
+	DescribeTable("net-attach-def plugins",
+		func(config string, expectedErr string) {
+			warnings, err := checkPluginBinaries([]byte(config))
+			Expect(warnings).To(BeEmpty())
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("installed plugin", `{"cniVersion": "1.0.0", "type": "macvlan", "ipam": {"type": "host-local"}}`, ""),
+		Entry("no version", `{"type": "macvlan"}`, ""),
+		Entry("missing plugin", `{"cniVersion": "1.0.0", "type": "ipvlan"}`,
+			`are missing or do not support the net-attach-def: spec.config.type: ipvlan: failed to find plugin "ipvlan"`),
+		Entry("missing IPAM plugin", `{"cniVersion": "1.0.0", "type": "macvlan", "ipam": {"type": "whereabouts"}}`,
+			`spec.config.ipam.type: whereabouts: failed to find plugin "whereabouts"`),
+		Entry("unsupported version", `{"cniVersion": "1.1.0", "type": "macvlan", "ipam": {"type": "host-local"}}`,
+			"spec.config.type: macvlan supports CNI versions 0.3.1, 0.4.0, 1.0.0, not 1.1.0"),
+		Entry("conflist plugin", `{"cniVersion": "1.0.0", "name": "n", "plugins": [{"type": "macvlan"}, {"type": "tuning"}]}`,
+			"spec.config.plugins[1].type: tuning supports CNI versions 0.1.0, 0.2.0, not 1.0.0"),
+		Entry("resolved list version", `{"cniVersion": "0.3.1", "cniVersions": ["1.1.0"], "name": "n", "plugins": [{"type": "macvlan"}]}`,
+			"spec.config.plugins[0].type: macvlan supports CNI versions 0.3.1, 0.4.0, 1.0.0, not 1.1.0"),
+	)
+
+	It("should probe each binary once", func() {
+		writeFakePlugin(binDir, "macvlan-copy", "0.3.1", "0.4.0", "1.0.0")
+		for i := 0; i < 3; i++ {
+			_, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "macvlan"}`))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(pluginRuns(binDir)).To(Equal(1))
+
+		By("sharing the result with identical binaries")
+		_, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "macvlan-copy"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginRuns(binDir)).To(Equal(1))
+
+		By("probing updated binaries again")
+		writeFakePlugin(binDir, "macvlan", "0.3.1", "0.4.0", "1.0.0", "1.1.0")
+		_, err = checkPluginBinaries([]byte(`{"cniVersion": "1.1.0", "type": "macvlan"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginRuns(binDir)).To(Equal(2))
+	})
+
+	It("should not hash unchanged binaries again", func() {
+		_, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "macvlan"}`))
+		Expect(err).NotTo(HaveOccurred())
+		path := filepath.Join(binDir, "macvlan")
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		// same size and modification time, so the cached hash and versions
+		// are used
+		writeFakePlugin(binDir, "macvlan", "0.3.1", "0.4.0", "9.9.9")
+		Expect(os.Chtimes(path, info.ModTime(), info.ModTime())).To(Succeed())
+		_, err = checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "macvlan"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginRuns(binDir)).To(Equal(1))
+	})
+
+	It("should not run failing binaries again until they change", func() {
+		pluginProbeTimeout = 200 * time.Millisecond
+		writeHangingPlugin(binDir, "hanging")
+		for i := 0; i < 2; i++ {
+			_, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "hanging"}`))
+			Expect(err).To(MatchError(ContainSubstring("spec.config.type: hanging: did not report its versions within 200ms")))
+		}
+		Expect(pluginRuns(binDir)).To(Equal(1))
+
+		By("probing replaced binaries again")
+		path := filepath.Join(binDir, "hanging")
+		later := time.Now().Add(time.Minute)
+		Expect(os.Chtimes(path, later, later)).To(Succeed())
+		_, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "hanging"}`))
+		Expect(err).To(HaveOccurred())
+		Expect(pluginRuns(binDir)).To(Equal(2))
+	})
+
+	It("should skip the binaries left once the probing budget is spent", func() {
+		pluginProbeTimeout = time.Second
+		pluginProbeBudget = 300 * time.Millisecond
+		writeHangingPlugin(binDir, "hanging")
+		writeHangingPlugin(binDir, "hanging-ipam")
+		start := time.Now()
+		_, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "hanging", "ipam": {"type": "hanging-ipam"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(pluginRuns(binDir)).To(Equal(1))
+
+		By("not caching probes cut short by the budget")
+		pluginProbeBudget = 5 * time.Second
+		pluginProbeTimeout = 200 * time.Millisecond
+		_, err = checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "hanging"}`))
+		Expect(err).To(MatchError(ContainSubstring("did not report its versions")))
+	})
+
+	It("should warn with the warn policy", func() {
+		Expect(SetPluginBinaryPolicy(PolicyWarn)).To(Succeed())
+		warnings, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "ipvlan"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring(`failed to find plugin "ipvlan"`)))
+	})
+
+	It("should not probe without CNI bin directory", func() {
+		Expect(SetCNIBinDir("")).To(Succeed())
+		_, err := checkPluginBinaries([]byte(`{"cniVersion": "1.0.0", "type": "ipvlan"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pluginRuns(binDir)).To(Equal(0))
+	})
+
+	It("should reject invalid CNI bin directories", func() {
+		Expect(SetCNIBinDir(filepath.Join(binDir, "missing"))).To(MatchError(ContainSubstring("invalid CNI bin directory")))
+		Expect(SetCNIBinDir(filepath.Join(binDir, "macvlan"))).To(MatchError(ContainSubstring("not a directory")))
+	})
+
+	It("should deny missing plugins on net-attach-def validation", func() {
+		allowed, _, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net",
+			`{"cniVersion": "1.0.0", "type": "ipvlan", "master": "eth1"}`), authenticationv1.UserInfo{})
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring(`failed to find plugin "ipvlan"`)))
+	})
+})
@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/containernetworking/cni/libcni"
+	"github.com/golang/glog"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+)
+
+// Runtime capabilities multus passes to the plugin chain for the fields of a
+// network selection element
+const (
+	capabilityIPs            = "ips"
+	capabilityMAC            = "mac"
+	capabilityPortMappings   = "portMappings"
+	capabilityBandwidth      = "bandwidth"
+	capabilityInfinibandGUID = "infinibandGUID"
+)
+
+var capabilityPolicy = PolicyDeny
+
+// SetCapabilityPolicy sets how pods requesting runtime capabilities that the
+// plugin chain of the referenced NAD does not support are handled
+func SetCapabilityPolicy(policy string) error {
+	p, err := parsePolicy("capability", policy)
+	if err != nil {
+		return err
+	}
+	capabilityPolicy = p
+	return nil
+}
+
+// requestedCapabilities returns the runtime capabilities a network selection
+// element needs from the plugin chain
+func requestedCapabilities(network *types.NetworkSelectionElement) []string {
+	var capabilities []string
+	if len(network.IPRequest) > 0 {
+		capabilities = append(capabilities, capabilityIPs)
+	}
+	if network.MacRequest != "" {
+		capabilities = append(capabilities, capabilityMAC)
+	}
+	if len(network.PortMappingsRequest) > 0 {
+		capabilities = append(capabilities, capabilityPortMappings)
+	}
+	if network.BandwidthRequest != nil {
+		capabilities = append(capabilities, capabilityBandwidth)
+	}
+	if network.InfinibandGUIDRequest != "" {
+		capabilities = append(capabilities, capabilityInfinibandGUID)
+	}
+	return capabilities
+}
+
+// loadNetConfList parses the config of a NAD with libcni the way multus does,
+// turning a single plugin config into a list of one plugin
+func loadNetConfList(netAttachDef *netv1.NetworkAttachmentDefinition) (*libcni.NetworkConfigList, error) {
+	confBytes, err := preprocessCNIConfig(netAttachDef.Name, []byte(netAttachDef.Spec.Config))
+	if err != nil {
+		return nil, err
+	}
+	if confList, err := libcni.ConfListFromBytes(confBytes); err == nil && len(confList.Plugins) > 0 {
+		return confList, nil
+	}
+	conf, err := libcni.ConfFromBytes(confBytes)
+	if err != nil {
+		return nil, err
+	}
+	return libcni.ConfListFromConf(conf)
+}
+
+// missingCapabilities returns the capabilities of requested that no plugin of
+// the chain advertises
+func missingCapabilities(confList *libcni.NetworkConfigList, requested []string) []string {
+	var missing []string
+	for _, capability := range requested {
+		supported := false
+		for _, plugin := range confList.Plugins {
+			if plugin.Network.Capabilities[capability] {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			missing = append(missing, capability)
+		}
+	}
+	return missing
+}
+
+// pluginTypes returns the plugin types of the chain in order
+func pluginTypes(confList *libcni.NetworkConfigList) []string {
+	names := make([]string, 0, len(confList.Plugins))
+	for _, plugin := range confList.Plugins {
+		names = append(names, plugin.Network.Type)
+	}
+	return names
+}
+
+// checkCapabilities verifies the plugin chain of every NAD referenced by the
+// pod supports the runtime capabilities the pod requests from it. Depending
+// on the capability policy the mismatches are returned as an error or as
+// warnings.
+func checkCapabilities(networks []*types.NetworkSelectionElement, podNamespace string) ([]string, error) {
+	if capabilityPolicy == PolicyIgnore || len(networks) == 0 {
+		return nil, nil
+	}
+	if !netAttachDefCacheReady() {
+		glog.Warning("net-attach-def cache is not ready, skipping capability check")
+		return nil, nil
+	}
+
+	var mismatches []string
+	for i, network := range networks {
+		requested := requestedCapabilities(network)
+		if len(requested) == 0 {
+			continue
+		}
+		netAttachDef, err := getNetAttachDef(resolveNetworkNamespace(network, podNamespace), network.Name)
+		if err != nil {
+			return nil, err
+		}
+		// missing NADs are reported by the existence check, and NADs without
+		// config are read by multus from the node which cannot be checked
+		if netAttachDef == nil || netAttachDef.Spec.Config == "" {
+			continue
+		}
+		confList, err := loadNetConfList(netAttachDef)
+		if err != nil {
+			glog.Infof("skipping capability check of %s/%s: %v", netAttachDef.Namespace, netAttachDef.Name, err)
+			continue
+		}
+		for _, capability := range missingCapabilities(confList, requested) {
+			mismatches = append(mismatches, fmt.Sprintf("%s requests the %s capability which no plugin of its chain (%s) advertises",
+				describeAttachment(networks, i, podNamespace), capability, strings.Join(pluginTypes(confList), ", ")))
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil, nil
+	}
+
+	msg := fmt.Sprintf("%s annotation requests unsupported runtime capabilities: %s", networksAnnotationKey, strings.Join(mismatches, "; "))
+	glog.Info(msg)
+	return applyPolicy(capabilityPolicy, msg)
+}
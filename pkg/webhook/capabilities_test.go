@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Capability check", func() {
+
+	BeforeEach(func() {
+		useNetAttachDefs(
+			newNetAttachDef("default", "static-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "capabilities": {"ips": true}, "ipam": {"type": "static"}}`),
+			newNetAttachDef("default", "chain-conf", `{"cniVersion": "0.4.0", "name": "chain", "plugins": [
+				{"type": "macvlan", "master": "eth1", "ipam": {"type": "static"}, "capabilities": {"ips": true}},
+				{"type": "tuning", "capabilities": {"mac": true}},
+				{"type": "portmap", "capabilities": {"portMappings": true}}
+			]}`),
+			newNetAttachDef("default", "plain-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1"}`),
+			newNetAttachDef("default", "file-conf", ""),
+		)
+	})
+
+	AfterEach(func() {
+		netAttachDefIndexer = nil
+		Expect(SetCapabilityPolicy(PolicyDeny)).To(Succeed())
+	})
+
+	DescribeTable("pod requests against NAD capabilities",
+		func(networks string, message string) {
+			allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: networks}))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			}
+		},
+		Entry("no runtime requests", `plain-conf`, ""),
+		Entry("static IP on a plugin with the ips capability", `[{"name": "static-conf", "ips": ["10.1.1.10/24"]}]`, ""),
+		Entry("every capability of a chain", `[{"name": "chain-conf", "ips": ["10.1.1.10/24"], "mac": "02:23:45:67:89:01",
+			"portMappings": [{"hostPort": 8080, "containerPort": 80}]}]`, ""),
+		Entry("config on the node is not checked", `[{"name": "file-conf", "mac": "02:23:45:67:89:01"}]`, ""),
+		Entry("mac without tuning", `[{"name": "static-conf", "mac": "02:23:45:67:89:01"}]`,
+			"attachment 1 (default/static-conf) requests the mac capability which no plugin of its chain (macvlan) advertises"),
+		Entry("bandwidth missing from a chain", `[{"name": "chain-conf", "bandwidth": {"ingressRate": 1000, "ingressBurst": 2000}}]`,
+			"requests the bandwidth capability which no plugin of its chain (macvlan, tuning, portmap) advertises"),
+		Entry("static IP without capabilities", `[{"name": "plain-conf", "ips": ["10.1.1.10/24"]}]`, "requests the ips capability"),
+	)
+
+	It("should only warn when configured to", func() {
+		Expect(SetCapabilityPolicy(PolicyWarn)).To(Succeed())
+		allowed, warnings, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: `[{"name": "plain-conf", "ips": ["10.1.1.10/24"]}]`}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(warnings).To(ConsistOf(ContainSubstring("ips capability")))
+	})
+})
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// mainPluginTypes are the plugin types creating the interface of an
+// attachment, which must come first in a chain
+var mainPluginTypes = map[string]bool{
+	"bridge":              true,
+	"dummy":               true,
+	"host-device":         true,
+	"ib-sriov":            true,
+	"ipvlan":              true,
+	"loopback":            true,
+	"macvlan":             true,
+	"ovn-k8s-cni-overlay": true,
+	"ptp":                 true,
+	"sriov":               true,
+	"tap":                 true,
+	"vlan":                true,
+}
+
+// metaPluginTypes are the plugin types acting on the interface created by
+// the previous plugins of the chain
+var metaPluginTypes = map[string]bool{
+	"bandwidth": true,
+	"firewall":  true,
+	"portmap":   true,
+	"sbr":       true,
+	"tuning":    true,
+	"vrf":       true,
+}
+
+// uniquePluginTypes are the meta plugin types that may appear at most once in
+// a chain, as a second instance would install conflicting host rules
+var uniquePluginTypes = map[string]bool{
+	"portmap": true,
+}
+
+// delegatingPluginTypes are the meta plugins delegating to net-attach-defs,
+// which would recurse when used in a net-attach-def
+var delegatingPluginTypes = map[string]bool{
+	"multus":      true,
+	"multus-shim": true,
+}
+
+// validatePluginChain checks the order of the plugins of a config: exactly
+// one main plugin comes first, followed by meta plugins, portmap appearing at
+// most once, and no plugin delegates to net-attach-defs again. Plugin types that are
+// neither main nor meta plugins are allowed anywhere.
+func validatePluginChain(plugins []pluginConf) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]int{}
+	for i, plugin := range plugins {
+		path := plugin.Path.Child("type")
+		switch {
+		case delegatingPluginTypes[plugin.Type]:
+			allErrs = append(allErrs, field.Forbidden(path,
+				fmt.Sprintf("plugin at index %d: %s delegates to net-attach-defs and may not be used in a net-attach-def", i, plugin.Type)))
+		case i == 0 && metaPluginTypes[plugin.Type]:
+			allErrs = append(allErrs, field.Invalid(path, plugin.Type,
+				fmt.Sprintf("plugin at index %d: %s is a meta plugin acting on an existing interface, the chain must start with a main plugin", i, plugin.Type)))
+		case i > 0 && mainPluginTypes[plugin.Type]:
+			allErrs = append(allErrs, field.Invalid(path, plugin.Type,
+				fmt.Sprintf("plugin at index %d: %s is a main plugin creating an interface, only the first plugin of the chain may be one", i, plugin.Type)))
+		case uniquePluginTypes[plugin.Type]:
+			if first, ok := seen[plugin.Type]; ok {
+				allErrs = append(allErrs, field.Duplicate(path,
+					fmt.Sprintf("plugin at index %d: %s already appears at index %d", i, plugin.Type, first)))
+			}
+		}
+		if _, ok := seen[plugin.Type]; !ok {
+			seen[plugin.Type] = i
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plugin chain validation", func() {
+
+	DescribeTable("plugin order",
+		func(config string, expectedErrs []string) {
+			plugins, err := getPluginConfs([]byte(config))
+			Expect(err).NotTo(HaveOccurred())
+			errs := validatePluginChain(plugins)
+			messages := make([]string, 0, len(errs))
+			for _, e := range errs {
+				messages = append(messages, e.Error())
+			}
+			Expect(messages).To(Equal(expectedErrs))
+		},
+		Entry("standalone main plugin", `{"type": "macvlan"}`, []string{}),
+		Entry("standalone unknown plugin", `{"type": "my-plugin"}`, []string{}),
+		Entry("main plugin followed by meta plugins",
+			`{"plugins": [{"type": "bridge"}, {"type": "tuning"}, {"type": "portmap"}, {"type": "bandwidth"}, {"type": "firewall"}, {"type": "sbr"}, {"type": "vrf"}]}`,
+			[]string{}),
+		Entry("unknown plugins anywhere",
+			`{"plugins": [{"type": "my-plugin"}, {"type": "tuning"}, {"type": "route-override"}]}`, []string{}),
+		Entry("standalone meta plugin", `{"type": "tuning"}`,
+			[]string{`spec.config.type: Invalid value: "tuning": plugin at index 0: tuning is a meta plugin acting on an existing interface, the chain must start with a main plugin`}),
+		Entry("chain starting with a meta plugin",
+			`{"plugins": [{"type": "bandwidth"}, {"type": "macvlan"}]}`,
+			[]string{
+				`spec.config.plugins[0].type: Invalid value: "bandwidth": plugin at index 0: bandwidth is a meta plugin acting on an existing interface, the chain must start with a main plugin`,
+				`spec.config.plugins[1].type: Invalid value: "macvlan": plugin at index 1: macvlan is a main plugin creating an interface, only the first plugin of the chain may be one`,
+			}),
+		Entry("second main plugin",
+			`{"plugins": [{"type": "macvlan"}, {"type": "tuning"}, {"type": "ipvlan"}]}`,
+			[]string{`spec.config.plugins[2].type: Invalid value: "ipvlan": plugin at index 2: ipvlan is a main plugin creating an interface, only the first plugin of the chain may be one`}),
+		Entry("repeated tuning plugin",
+			`{"plugins": [{"type": "macvlan"}, {"type": "tuning"}, {"type": "sbr"}, {"type": "tuning"}]}`, []string{}),
+		Entry("repeated portmap plugin",
+			`{"plugins": [{"type": "bridge"}, {"type": "portmap"}, {"type": "tuning"}, {"type": "portmap"}]}`,
+			[]string{`spec.config.plugins[3].type: Duplicate value: "plugin at index 3: portmap already appears at index 1"`}),
+		Entry("nested multus",
+			`{"plugins": [{"type": "macvlan"}, {"type": "multus"}]}`,
+			[]string{`spec.config.plugins[1].type: Forbidden: plugin at index 1: multus delegates to net-attach-defs and may not be used in a net-attach-def`}),
+		Entry("standalone multus shim", `{"type": "multus-shim"}`,
+			[]string{`spec.config.type: Forbidden: plugin at index 0: multus-shim delegates to net-attach-defs and may not be used in a net-attach-def`}),
+	)
+
+	It("should deny invalid chains on net-attach-def validation", func() {
+		errs := validatePluginConfs([]byte(`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "tuning"}, {"type": "macvlan", "master": "eth1"}]}`))
+		Expect(errs.ToAggregate()).To(MatchError(ContainSubstring("spec.config.plugins[0].type")))
+	})
+})
@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/containernetworking/cni/pkg/version"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// conflistFeature is a conflist field switching off an operation that only
+// exists from some CNI version on
+type conflistFeature struct {
+	Key        string
+	Operation  string
+	MinVersion string
+}
+
+// conflistFeatures are the conflist fields checked against the version of
+// the list
+var conflistFeatures = []conflistFeature{
+	{Key: "disableCheck", Operation: "CHECK", MinVersion: "0.4.0"},
+	{Key: "disableGC", Operation: "GC", MinVersion: "1.1.0"},
+}
+
+// resolveCNIVersion returns the version libcni runs a config with: the
+// highest of cniVersion and the cniVersions entries that libcni implements
+func resolveCNIVersion(cniVersion string, cniVersions []string) string {
+	resolved := ""
+	for _, v := range append(cniVersions, cniVersion) {
+		if v == "" {
+			continue
+		}
+		if gt, err := version.GreaterThan(v, version.Current()); err != nil || gt {
+			continue
+		}
+		if resolved == "" {
+			resolved = v
+		} else if gt, _ := version.GreaterThan(v, resolved); gt {
+			resolved = v
+		}
+	}
+	return resolved
+}
+
+// validateCNIVersion checks a version is one of the versions libcni supports
+func validateCNIVersion(v string, path *field.Path) field.ErrorList {
+	if _, _, _, err := version.ParseVersion(v); err != nil {
+		return field.ErrorList{field.Invalid(path, v, err.Error())}
+	}
+	if !containsString(version.All.SupportedVersions(), v) {
+		return field.ErrorList{field.NotSupported(path, v, version.All.SupportedVersions())}
+	}
+	return nil
+}
+
+// validateCNIVersions checks the cniVersion and cniVersions of a config are
+// versions libcni supports, resolves the version of the config the way libcni
+// does and checks the version of every plugin of a conflist matches it. The
+// conflist fields switching off operations the resolved version does not
+// have are returned as warnings.
+func validateCNIVersions(confBytes []byte) ([]string, field.ErrorList) {
+	allErrs := field.ErrorList{}
+	var conf map[string]interface{}
+	if err := json.Unmarshal(confBytes, &conf); err != nil {
+		return nil, allErrs
+	}
+	root := field.NewPath("spec", "config")
+
+	cniVersion := ""
+	if raw, ok := conf["cniVersion"]; ok {
+		path := root.Child("cniVersion")
+		s, ok := raw.(string)
+		if !ok {
+			return nil, append(allErrs, field.Invalid(path, raw, "must be a string"))
+		}
+		if s != "" {
+			if errs := validateCNIVersion(s, path); len(errs) > 0 {
+				return nil, append(allErrs, errs...)
+			}
+		}
+		cniVersion = s
+	}
+
+	var warnings []string
+	var cniVersions []string
+	if raw, ok := conf["cniVersions"]; ok {
+		path := root.Child("cniVersions")
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil, append(allErrs, field.Invalid(path, raw, "must be a list of versions"))
+		}
+		for i, item := range list {
+			s, ok := item.(string)
+			if !ok {
+				allErrs = append(allErrs, field.Invalid(path.Index(i), item, "must be a string"))
+				continue
+			}
+			if containsString(cniVersions, s) {
+				allErrs = append(allErrs, field.Duplicate(path.Index(i), s))
+				continue
+			}
+			cniVersions = append(cniVersions, s)
+			if gt, err := version.GreaterThan(s, version.Current()); err == nil && gt {
+				// newer versions are meant for newer runtimes, libcni skips them
+				warnings = append(warnings, fmt.Sprintf("%s: version %s is newer than %s, the latest version libcni supports, and is ignored", path.Index(i), s, version.Current()))
+				continue
+			}
+			allErrs = append(allErrs, validateCNIVersion(s, path.Index(i))...)
+		}
+		if len(allErrs) > 0 {
+			return nil, allErrs
+		}
+		if resolveCNIVersion(cniVersion, cniVersions) == "" {
+			return nil, append(allErrs, field.Invalid(path, cniVersions,
+				fmt.Sprintf("none of the versions is supported by libcni, whose latest version is %s", version.Current())))
+		}
+	}
+	resolved := resolveCNIVersion(cniVersion, cniVersions)
+
+	if _, isList := conf["plugins"]; isList {
+		plugins, err := getPluginConfs(confBytes)
+		if err != nil {
+			return nil, allErrs
+		}
+		for _, plugin := range plugins {
+			v, ok := plugin.Raw["cniVersion"]
+			if !ok || v == "" || v == resolved {
+				continue
+			}
+			allErrs = append(allErrs, field.Invalid(plugin.Path.Child("cniVersion"), v,
+				fmt.Sprintf("must match the version of the list, %s, which libcni runs every plugin with", describeCNIVersion(resolved))))
+		}
+	}
+
+	for _, feature := range conflistFeatures {
+		raw, ok := conf[feature.Key]
+		if !ok {
+			continue
+		}
+		path := root.Child(feature.Key)
+		enabled, ok := raw.(bool)
+		if s, isString := raw.(string); isString {
+			switch strings.ToLower(s) {
+			case "true":
+				enabled, ok = true, true
+			case "false":
+				enabled, ok = false, true
+			}
+		}
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(path, raw, "must be a boolean"))
+			continue
+		}
+		if !enabled || resolved == "" {
+			continue
+		}
+		if lt, err := version.GreaterThan(feature.MinVersion, resolved); err == nil && lt {
+			warnings = append(warnings, fmt.Sprintf("%s: %s only exists from cniVersion %s on, %s has no effect with %s",
+				path, feature.Operation, feature.MinVersion, feature.Key, resolved))
+		}
+	}
+	return warnings, allErrs
+}
+
+// describeCNIVersion names a resolved version in messages
+func describeCNIVersion(v string) string {
+	if v == "" {
+		return "unset"
+	}
+	return v
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+var _ = Describe("CNI version validation", func() {
+
+	DescribeTable("cniVersion and cniVersions",
+		func(config string, expectedWarnings []string, expectedErr string) {
+			warnings, errs := validateCNIVersions([]byte(config))
+			if expectedErr == "" {
+				Expect(errs).To(BeEmpty())
+			} else {
+				Expect(errs.ToAggregate()).To(MatchError(ContainSubstring(expectedErr)))
+			}
+			Expect(warnings).To(Equal(expectedWarnings))
+		},
+		Entry("supported version", `{"cniVersion": "1.0.0", "type": "macvlan"}`, nil, ""),
+		Entry("no version", `{"type": "macvlan"}`, nil, ""),
+		Entry("unsupported version", `{"cniVersion": "0.5.0", "type": "macvlan"}`, nil,
+			`spec.config.cniVersion: Unsupported value: "0.5.0"`),
+		Entry("malformed version", `{"cniVersion": "one", "type": "macvlan"}`, nil,
+			`spec.config.cniVersion: Invalid value: "one"`),
+		Entry("non string version", `{"cniVersion": 1, "type": "macvlan"}`, nil,
+			"spec.config.cniVersion: Invalid value: 1: must be a string"),
+		Entry("list versions", `{"cniVersion": "0.4.0", "cniVersions": ["0.4.0", "1.0.0"], "name": "n", "plugins": [{"type": "macvlan"}]}`, nil, ""),
+		Entry("newer list version",
+			`{"cniVersions": ["1.0.0", "2.0.0"], "name": "n", "plugins": [{"type": "macvlan"}]}`,
+			[]string{"spec.config.cniVersions[1]: version 2.0.0 is newer than 1.1.0, the latest version libcni supports, and is ignored"}, ""),
+		Entry("only newer list versions",
+			`{"cniVersions": ["2.0.0"], "name": "n", "plugins": [{"type": "macvlan"}]}`, nil,
+			`spec.config.cniVersions: Invalid value: ["2.0.0"]: none of the versions is supported by libcni`),
+		Entry("unsupported list version",
+			`{"cniVersions": ["1.0.0", "0.5.0"], "name": "n", "plugins": [{"type": "macvlan"}]}`, nil,
+			`spec.config.cniVersions[1]: Unsupported value: "0.5.0"`),
+		Entry("duplicate list version",
+			`{"cniVersions": ["1.0.0", "1.0.0"], "name": "n", "plugins": [{"type": "macvlan"}]}`, nil,
+			`spec.config.cniVersions[1]: Duplicate value: "1.0.0"`),
+		Entry("non list versions",
+			`{"cniVersions": "1.0.0", "name": "n", "plugins": [{"type": "macvlan"}]}`, nil,
+			`spec.config.cniVersions: Invalid value: "1.0.0": must be a list of versions`),
+		Entry("plugin version matching the list", `{"cniVersion": "0.4.0", "name": "n", "plugins": [{"cniVersion": "0.4.0", "type": "macvlan"}]}`, nil, ""),
+		Entry("plugin version matching the resolved version",
+			`{"cniVersion": "0.4.0", "cniVersions": ["1.0.0"], "name": "n", "plugins": [{"cniVersion": "1.0.0", "type": "macvlan"}]}`, nil, ""),
+		Entry("plugin version not matching the list",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan"}, {"cniVersion": "1.0.0", "type": "tuning"}]}`, nil,
+			`spec.config.plugins[1].cniVersion: Invalid value: "1.0.0": must match the version of the list, 0.4.0, which libcni runs every plugin with`),
+		Entry("plugin version without list version",
+			`{"name": "n", "plugins": [{"cniVersion": "1.0.0", "type": "macvlan"}]}`, nil,
+			"must match the version of the list, unset, which libcni runs every plugin with"),
+		Entry("disableCheck with CHECK", `{"cniVersion": "0.4.0", "disableCheck": true, "name": "n", "plugins": [{"type": "macvlan"}]}`, nil, ""),
+		Entry("disableCheck without CHECK",
+			`{"cniVersion": "0.3.1", "disableCheck": "true", "name": "n", "plugins": [{"type": "macvlan"}]}`,
+			[]string{"spec.config.disableCheck: CHECK only exists from cniVersion 0.4.0 on, disableCheck has no effect with 0.3.1"}, ""),
+		Entry("disableGC without GC",
+			`{"cniVersion": "1.0.0", "disableGC": true, "name": "n", "plugins": [{"type": "macvlan"}]}`,
+			[]string{"spec.config.disableGC: GC only exists from cniVersion 1.1.0 on, disableGC has no effect with 1.0.0"}, ""),
+		Entry("disableGC resolved from the list versions",
+			`{"cniVersion": "1.0.0", "cniVersions": ["1.1.0"], "disableGC": true, "name": "n", "plugins": [{"type": "macvlan"}]}`, nil, ""),
+		Entry("disabled feature set to false", `{"cniVersion": "0.3.1", "disableCheck": false, "name": "n", "plugins": [{"type": "macvlan"}]}`, nil, ""),
+		Entry("non boolean feature",
+			`{"cniVersion": "1.1.0", "disableGC": "yes", "name": "n", "plugins": [{"type": "macvlan"}]}`, nil,
+			`spec.config.disableGC: Invalid value: "yes": must be a boolean`),
+	)
+
+	It("should return the version warnings on net-attach-def validation", func() {
+		allowed, warnings, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net",
+			`{"cniVersion": "0.3.1", "disableCheck": true, "name": "my-net", "plugins": [{"type": "macvlan", "master": "eth1"}]}`), authenticationv1.UserInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(warnings).To(ContainElement(ContainSubstring("disableCheck has no effect with 0.3.1")))
+	})
+
+	It("should deny mismatching plugin versions on net-attach-def validation", func() {
+		allowed, _, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net",
+			`{"cniVersion": "0.4.0", "name": "my-net", "plugins": [{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1"}]}`), authenticationv1.UserInfo{})
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("spec.config.plugins[0].cniVersion")))
+	})
+})
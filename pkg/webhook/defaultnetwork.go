@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// defaultNetworkAnnotationKey replaces the cluster default network of a pod
+// with a net-attach-def
+const defaultNetworkAnnotationKey = "v1.multus-cni.io/default-network"
+
+// Whether pods may override the cluster default network
+const (
+	// DefaultNetworkOverrideAllow lets pods set the default-network annotation
+	DefaultNetworkOverrideAllow = "allow"
+	// DefaultNetworkOverrideDeny denies pods setting the default-network
+	// annotation
+	DefaultNetworkOverrideDeny = "deny"
+
+	// defaultNetworkOverrideLabel is the namespace label overriding the
+	// cluster-wide default network override
+	defaultNetworkOverrideLabel = "k8s.v1.cni.cncf.io/default-network-override"
+)
+
+// DefaultMultusNamespace is the multusNamespace of a default Multus
+// deployment
+const DefaultMultusNamespace = "kube-system"
+
+var (
+	defaultNetworkOverride = DefaultNetworkOverrideAllow
+
+	// multusNamespace is the namespace Multus resolves default-network
+	// annotations without a namespace against
+	multusNamespace = DefaultMultusNamespace
+)
+
+// SetMultusNamespace sets the namespace Multus looks up the net-attach-defs
+// of default-network annotations without a namespace in
+func SetMultusNamespace(namespace string) error {
+	if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+		return errors.Errorf("invalid Multus namespace '%s': %s", namespace, strings.Join(msgs, ", "))
+	}
+	multusNamespace = namespace
+	return nil
+}
+
+func parseDefaultNetworkOverride(override string) (string, error) {
+	switch override {
+	case DefaultNetworkOverrideAllow, DefaultNetworkOverrideDeny:
+		return override, nil
+	}
+	return "", errors.Errorf("invalid default network override '%s', must be one of %s or %s", override, DefaultNetworkOverrideAllow, DefaultNetworkOverrideDeny)
+}
+
+// SetDefaultNetworkOverride sets whether pods may override the cluster
+// default network in the namespaces not labeled otherwise
+func SetDefaultNetworkOverride(override string) error {
+	o, err := parseDefaultNetworkOverride(override)
+	if err != nil {
+		return err
+	}
+	defaultNetworkOverride = o
+	return nil
+}
+
+// namespaceDefaultNetworkOverride returns whether the pods of a namespace may
+// override the cluster default network, i.e. the value of the namespace label
+// if set and valid, else the cluster-wide one
+func namespaceDefaultNetworkOverride(namespace string) string {
+	ns := getNamespace(namespace)
+	if ns == nil {
+		return defaultNetworkOverride
+	}
+	label, ok := ns.Labels[defaultNetworkOverrideLabel]
+	if !ok {
+		return defaultNetworkOverride
+	}
+	o, err := parseDefaultNetworkOverride(label)
+	if err != nil {
+		glog.Warningf("ignoring label of namespace %s: %v", namespace, err)
+		return defaultNetworkOverride
+	}
+	return o
+}
+
+// analyzeDefaultNetworkAnnotation checks the pod may override the cluster
+// default network, and runs the syntax, isolation and existence checks of the
+// networks annotation on the single net-attach-def the default-network
+// annotation refers to. Like Multus, a net-attach-def without namespace is
+// looked up in the Multus namespace, whose net-attach-defs any pod may use as
+// its default network.
+func analyzeDefaultNetworkAnnotation(annotation, podNamespace string, users []authenticationv1.UserInfo) ([]string, error) {
+	glog.Infof("Analyzing %s annotation: %s", defaultNetworkAnnotationKey, annotation)
+
+	if namespaceDefaultNetworkOverride(podNamespace) == DefaultNetworkOverrideDeny {
+		return nil, errors.Errorf("%s annotation is not allowed: pods of namespace %s may not override the cluster default network", defaultNetworkAnnotationKey, podNamespace)
+	}
+
+	networks, err := parsePodNetworkAnnotation(annotation, namespaceConstraint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation", defaultNetworkAnnotationKey)
+	}
+	if len(networks) != 1 {
+		return nil, errors.Errorf("%s annotation must refer to exactly one net-attach-def, found %d", defaultNetworkAnnotationKey, len(networks))
+	}
+	if networks[0].Namespace == "" || networks[0].Namespace == namespaceConstraint {
+		networks[0].Namespace = multusNamespace
+	}
+
+	if err := validateNetworkSelectionElements(defaultNetworkAnnotationKey, networks, podNamespace); err != nil {
+		return nil, err
+	}
+	if networks[0].Namespace != multusNamespace {
+		if err := validateNetworkNamespaces(defaultNetworkAnnotationKey, networks, podNamespace, users); err != nil {
+			return nil, err
+		}
+	}
+	return checkNetworksExist(defaultNetworkAnnotationKey, networks, podNamespace, users)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Default network annotation", func() {
+
+	BeforeEach(func() {
+		useNetAttachDefs(
+			newNetAttachDef("kube-system", "calico-conf", `{"cniVersion": "0.3.1", "type": "calico"}`),
+			newNetAttachDef("default", "local-conf", `{"cniVersion": "0.3.1", "type": "calico"}`),
+			newNetAttachDef("infra", "cluster-conf", `{"cniVersion": "0.3.1", "type": "calico"}`),
+		)
+		useNamespaces(map[string]map[string]string{
+			"default": nil,
+			"locked":  {defaultNetworkOverrideLabel: DefaultNetworkOverrideDeny},
+			"open":    {defaultNetworkOverrideLabel: DefaultNetworkOverrideAllow},
+			"invalid": {defaultNetworkOverrideLabel: "maybe"},
+		})
+	})
+
+	AfterEach(func() {
+		netAttachDefIndexer = nil
+		namespaceIndexer = nil
+		defaultNetworkOverride = DefaultNetworkOverrideAllow
+		multusNamespace = DefaultMultusNamespace
+	})
+
+	DescribeTable("default-network validation",
+		func(namespace, defaultNetwork string, message string) {
+			allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview(namespace, map[string]string{defaultNetworkAnnotationKey: defaultNetwork}))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("net-attach-def of the Multus namespace", "default", "calico-conf", ""),
+		Entry("explicit namespace", "default", "default/local-conf", ""),
+		Entry("explicit Multus namespace", "default", "kube-system/calico-conf", ""),
+		Entry("JSON form", "default", `[{"name": "calico-conf"}]`, ""),
+		Entry("net-attach-def of the pod namespace without namespace", "default", "local-conf",
+			"v1.multus-cni.io/default-network annotation refers to net-attach-defs that do not exist: kube-system/local-conf"),
+		Entry("invalid syntax", "default", "default/calico-conf@eth0/x", "invalid v1.multus-cni.io/default-network annotation"),
+		Entry("more than one net-attach-def", "default", "calico-conf,calico-conf", "must refer to exactly one net-attach-def, found 2"),
+		Entry("invalid selection element", "default", `[{"name": "calico-conf", "ips": ["not-an-ip"]}]`,
+			"v1.multus-cni.io/default-network annotation has invalid network selection elements"),
+		Entry("other namespace", "default", "infra/cluster-conf",
+			"v1.multus-cni.io/default-network annotation refers to net-attach-defs of other namespaces that are not granted"),
+		Entry("missing net-attach-def", "default", "missing-conf",
+			"v1.multus-cni.io/default-network annotation refers to net-attach-defs that do not exist: kube-system/missing-conf"),
+		Entry("namespace denying the override", "locked", "calico-conf", "pods of namespace locked may not override the cluster default network"),
+		// the override check passes, falling back to the cluster-wide allow
+		Entry("namespace with an invalid label", "invalid", "calico-conf", ""),
+	)
+
+	It("should apply the cluster-wide override unless the namespace label sets it", func() {
+		Expect(SetDefaultNetworkOverride(DefaultNetworkOverrideDeny)).To(Succeed())
+
+		allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{defaultNetworkAnnotationKey: "calico-conf"}))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("pods of namespace default may not override the cluster default network")))
+
+		allowed, _, err = analyzeIsolationAnnotation(newPodAdmissionReview("open", map[string]string{defaultNetworkAnnotationKey: "calico-conf"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should look up net-attach-defs without namespace in the configured Multus namespace", func() {
+		Expect(SetMultusNamespace("infra")).To(Succeed())
+		allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{defaultNetworkAnnotationKey: "cluster-conf"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+
+		allowed, _, err = analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{defaultNetworkAnnotationKey: "calico-conf"}))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("net-attach-defs that do not exist: infra/calico-conf")))
+
+		Expect(SetMultusNamespace("Kube_System")).To(MatchError(ContainSubstring("invalid Multus namespace 'Kube_System'")))
+	})
+
+	It("should reject invalid overrides", func() {
+		Expect(SetDefaultNetworkOverride("maybe")).To(MatchError(ContainSubstring("invalid default network override 'maybe'")))
+	})
+})
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+const (
+	// forceDeleteAnnotationKey set to "true" on a NAD allows deleting it while
+	// running pods refer to it
+	forceDeleteAnnotationKey = "k8s.v1.cni.cncf.io/force-delete"
+
+	// maxListedPods is the number of pods named when denying a deletion
+	maxListedPods = 5
+)
+
+// deserializeDeletedNetworkAttachmentDefinition returns the NAD of a DELETE
+// request, which the API server sends as the old object
+func deserializeDeletedNetworkAttachmentDefinition(ar *admissionv1.AdmissionReview) (netv1.NetworkAttachmentDefinition, error) {
+	netAttachDef := netv1.NetworkAttachmentDefinition{}
+	err := json.Unmarshal(ar.Request.OldObject.Raw, &netAttachDef)
+	if err == nil && netAttachDef.Namespace == "" {
+		netAttachDef.Namespace = ar.Request.Namespace
+	}
+	if netAttachDef.Name == "" {
+		netAttachDef.Name = ar.Request.Name
+	}
+	return netAttachDef, err
+}
+
+// checkNetworkDeletion refuses the deletion of a NAD that running pods refer
+// to, unless the NAD carries the force delete annotation
+func checkNetworkDeletion(netAttachDef netv1.NetworkAttachmentDefinition) error {
+	if netAttachDef.Annotations[forceDeleteAnnotationKey] == "true" {
+		glog.Infof("net-attach-def %s/%s is force deleted", netAttachDef.Namespace, netAttachDef.Name)
+		return nil
+	}
+
+	pods, ready, err := podsUsingNetwork(netAttachDef.Namespace, netAttachDef.Name)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		glog.Warning("pod cache is not ready, skipping in-use check of net-attach-def deletion")
+		return nil
+	}
+	if len(pods) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	sort.Strings(names)
+	sample := strings.Join(names, ", ")
+	if len(names) > maxListedPods {
+		sample = fmt.Sprintf("%s and %d more", strings.Join(names[:maxListedPods], ", "), len(names)-maxListedPods)
+	}
+
+	err = errors.Errorf("net-attach-def %s/%s is used by %d running pods (%s); annotate it with %s=true to delete it anyway",
+		netAttachDef.Namespace, netAttachDef.Name, len(pods), sample, forceDeleteAnnotationKey)
+	glog.Info(err)
+	return err
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// sendDeleteRequest sends the DELETE AdmissionReview of the NAD to
+// ValidateHandler and returns the response
+func sendDeleteRequest(netAttachDef *netv1.NetworkAttachmentDefinition) *admissionv1.AdmissionResponse {
+	raw, err := json.Marshal(netAttachDef)
+	Expect(err).NotTo(HaveOccurred())
+	body, err := json.Marshal(&admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{Kind: "AdmissionReview", APIVersion: "admission.k8s.io/v1"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "fake-uid",
+			Name:      netAttachDef.Name,
+			Namespace: netAttachDef.Namespace,
+			Operation: admissionv1.Delete,
+			OldObject: runtime.RawExtension{Raw: raw},
+		},
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	req := httptest.NewRequest("POST", "https://fakewebhook/validate", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	ValidateHandler(w, req)
+
+	ar := &admissionv1.AdmissionReview{}
+	Expect(json.Unmarshal(w.Body.Bytes(), ar)).To(Succeed())
+	return ar.Response
+}
+
+var _ = Describe("Deletion of net-attach-defs in use", func() {
+
+	var netAttachDef *netv1.NetworkAttachmentDefinition
+
+	BeforeEach(func() {
+		netAttachDef = newNetAttachDef("default", "macvlan-conf", `{"cniVersion": "0.3.1", "type": "macvlan"}`)
+	})
+
+	AfterEach(func() {
+		SetNetworkPodLister(nil)
+	})
+
+	It("should allow deleting an unused net-attach-def", func() {
+		usePods()
+		Expect(sendDeleteRequest(netAttachDef).Allowed).To(BeTrue())
+	})
+
+	It("should deny deleting a net-attach-def used by running pods", func() {
+		var pods []*v1.Pod
+		for i := 0; i < 7; i++ {
+			pods = append(pods, newRunningPod("team-a", fmt.Sprintf("pod-%d", i), ""))
+		}
+		usePods(pods...)
+		resp := sendDeleteRequest(netAttachDef)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Message).To(Equal("net-attach-def default/macvlan-conf is used by 7 running pods " +
+			"(team-a/pod-0, team-a/pod-1, team-a/pod-2, team-a/pod-3, team-a/pod-4 and 2 more); " +
+			"annotate it with k8s.v1.cni.cncf.io/force-delete=true to delete it anyway"))
+	})
+
+	It("should allow a forced deletion", func() {
+		usePods(newRunningPod("default", "pod", ""))
+		netAttachDef.Annotations = map[string]string{forceDeleteAnnotationKey: "true"}
+		Expect(sendDeleteRequest(netAttachDef).Allowed).To(BeTrue())
+	})
+
+	It("should allow the deletion while the pod cache is not ready", func() {
+		Expect(sendDeleteRequest(netAttachDef).Allowed).To(BeTrue())
+	})
+})
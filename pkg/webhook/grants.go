@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	"github.com/pkg/errors"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// grantNetworkNamespaceIndex indexes NetworkAttachmentGrants by the
+// namespaces of the net-attach-defs they grant
+const grantNetworkNamespaceIndex = "networkNamespace"
+
+var (
+	grantInformer cache.SharedIndexInformer
+	// grantIndexer is the NetworkAttachmentGrant cache; no cross-namespace
+	// reference is granted while it is nil or not synced yet
+	grantIndexer cache.Indexer
+	grantSynced  cache.InformerSynced
+)
+
+// setupGrantInformer creates the NetworkAttachmentGrant informer
+func setupGrantInformer(client rest.Interface) {
+	grantInformer = cache.NewSharedIndexInformer(
+		cache.NewListWatchFromClient(client, "networkattachmentgrants", metav1.NamespaceAll, fields.Everything()),
+		&admissionv1alpha1.NetworkAttachmentGrant{},
+		informerResyncPeriod,
+		cache.Indexers{grantNetworkNamespaceIndex: grantNetworkNamespaceIndexFunc},
+	)
+	grantIndexer = grantInformer.GetIndexer()
+	grantSynced = grantInformer.HasSynced
+}
+
+func grantNetworkNamespaceIndexFunc(obj interface{}) ([]string, error) {
+	grant, ok := obj.(*admissionv1alpha1.NetworkAttachmentGrant)
+	if !ok {
+		return nil, nil
+	}
+	seen := map[string]bool{}
+	var namespaces []string
+	for _, network := range grant.Spec.Networks {
+		if !seen[network.Namespace] {
+			seen[network.Namespace] = true
+			namespaces = append(namespaces, network.Namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// grantCacheReady reports whether the NetworkAttachmentGrant cache can be
+// used
+func grantCacheReady() bool {
+	if grantIndexer == nil {
+		return false
+	}
+	return grantSynced == nil || grantSynced()
+}
+
+// grantAppliesTo reports whether the grant grants its networks to the
+// namespace
+func grantAppliesTo(grant *admissionv1alpha1.NetworkAttachmentGrant, namespace string) bool {
+	for _, ns := range grant.Spec.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	if grant.Spec.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(grant.Spec.NamespaceSelector)
+	if err != nil {
+		glog.Warningf("ignoring invalid namespace selector of NetworkAttachmentGrant %s: %v", grant.Name, err)
+		return false
+	}
+	ns := getNamespace(namespace)
+	if ns == nil {
+		return false
+	}
+	return selector.Matches(labels.Set(ns.Labels))
+}
+
+// grantsFor returns the grants of net-attach-defs of networkNamespace that
+// apply to podNamespace
+func grantsFor(podNamespace, networkNamespace string) []*admissionv1alpha1.NetworkAttachmentGrant {
+	if !grantCacheReady() {
+		return nil
+	}
+	objs, err := grantIndexer.ByIndex(grantNetworkNamespaceIndex, networkNamespace)
+	if err != nil {
+		return nil
+	}
+	var grants []*admissionv1alpha1.NetworkAttachmentGrant
+	for _, obj := range objs {
+		grant, ok := obj.(*admissionv1alpha1.NetworkAttachmentGrant)
+		if ok && grantAppliesTo(grant, podNamespace) {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+// networkGranted reports whether a NetworkAttachmentGrant grants the pods of
+// podNamespace the use of the net-attach-def namespace/name
+func networkGranted(podNamespace, namespace, name string) bool {
+	for _, grant := range grantsFor(podNamespace, namespace) {
+		for _, network := range grant.Spec.Networks {
+			if network.Namespace == namespace && (network.Name == "" || network.Name == name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// grantedNamespaces returns the namespaces other than podNamespace holding
+// net-attach-defs granted to podNamespace
+func grantedNamespaces(podNamespace string) []string {
+	if !grantCacheReady() {
+		return nil
+	}
+	seen := map[string]bool{podNamespace: true}
+	var namespaces []string
+	for _, obj := range grantIndexer.List() {
+		grant, ok := obj.(*admissionv1alpha1.NetworkAttachmentGrant)
+		if !ok || !grantAppliesTo(grant, podNamespace) {
+			continue
+		}
+		for _, network := range grant.Spec.Networks {
+			if !seen[network.Namespace] {
+				seen[network.Namespace] = true
+				namespaces = append(namespaces, network.Namespace)
+			}
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// validateNetworkNamespaces checks the pod only refers to net-attach-defs of
+// its own namespace, of other namespaces a NetworkAttachmentGrant grants to
+// its namespace, or that RBAC allows one of the users to use
+func validateNetworkNamespaces(annotation string, networks []*types.NetworkSelectionElement, podNamespace string, users []authenticationv1.UserInfo) error {
+	var denied []string
+	for i, network := range networks {
+		namespace := resolveNetworkNamespace(network, podNamespace)
+		if namespace == podNamespace || networkGranted(podNamespace, namespace, network.Name) {
+			continue
+		}
+		allowed, err := networkUseAllowed(users, namespace, network.Name)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			continue
+		}
+		denied = append(denied, fmt.Sprintf("%s: no NetworkAttachmentGrant grants namespace %s the use of net-attach-defs of namespace %s, and %s allowed to %s it",
+			describeAttachment(networks, i, podNamespace), podNamespace, namespace, describeNetworkUsers(users), networkUseVerb))
+	}
+	if len(denied) == 0 {
+		return nil
+	}
+	return errors.Errorf("%s annotation refers to net-attach-defs of other namespaces that are not granted: %s", annotation, strings.Join(denied, "; "))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// useGrants replaces the NetworkAttachmentGrant cache with one holding the
+// given grants
+func useGrants(grants ...*admissionv1alpha1.NetworkAttachmentGrant) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{grantNetworkNamespaceIndex: grantNetworkNamespaceIndexFunc})
+	for _, grant := range grants {
+		Expect(indexer.Add(grant)).To(Succeed())
+	}
+	grantIndexer = indexer
+	grantSynced = nil
+}
+
+var _ = Describe("Cross-namespace network grants", func() {
+
+	BeforeEach(func() {
+		useNamespaces(map[string]map[string]string{
+			"team-a": nil,
+			"team-b": {"storage-access": "true"},
+			"team-c": nil,
+		})
+		useGrants(
+			&admissionv1alpha1.NetworkAttachmentGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+				Spec: admissionv1alpha1.NetworkAttachmentGrantSpec{
+					Networks:          []admissionv1alpha1.NetworkReference{{Namespace: "infra", Name: "storage-net"}},
+					Namespaces:        []string{"team-a"},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"storage-access": "true"}},
+				},
+			},
+			&admissionv1alpha1.NetworkAttachmentGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+				Spec: admissionv1alpha1.NetworkAttachmentGrantSpec{
+					Networks:   []admissionv1alpha1.NetworkReference{{Namespace: "shared"}},
+					Namespaces: []string{"team-c"},
+				},
+			},
+		)
+	})
+
+	AfterEach(func() {
+		grantIndexer = nil
+		namespaceIndexer = nil
+	})
+
+	DescribeTable("pod network references",
+		func(podNamespace string, networks string, message string) {
+			allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview(podNamespace, map[string]string{networksAnnotationKey: networks}))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("implicit local reference", "team-a", "macvlan-conf", ""),
+		Entry("explicit same-namespace reference", "team-a", "team-a/macvlan-conf", ""),
+		Entry("explicit same-namespace reference in JSON form", "team-a", `[{"name": "macvlan-conf", "namespace": "team-a"}]`, ""),
+		Entry("granted by name", "team-a", "infra/storage-net", ""),
+		Entry("granted by selector", "team-b", "infra/storage-net", ""),
+		Entry("granted namespace", "team-c", "shared/any-net", ""),
+		Entry("other network of a granted namespace", "team-a", "infra/admin-net",
+			"attachment 1 (infra/admin-net): no NetworkAttachmentGrant grants namespace team-a the use of net-attach-defs of namespace infra"),
+		Entry("namespace without grant", "team-c", "macvlan-conf,infra/storage-net",
+			"attachment 2 (infra/storage-net): no NetworkAttachmentGrant grants namespace team-c"),
+	)
+
+	It("should deny cross-namespace references while grants are not known", func() {
+		grantIndexer = nil
+		allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("team-a", map[string]string{networksAnnotationKey: "infra/storage-net"}))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should suggest networks of granted namespaces", func() {
+		Expect(permittedNamespaces("team-c")).To(Equal([]string{"team-c", "shared"}))
+	})
+})
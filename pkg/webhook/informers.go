@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/glog"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	netattachdefClientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	informerResyncPeriod = time.Hour
+
+	// netAttachDefL2DomainIndex indexes NADs by the L2 domain their first
+	// interface-creating plugin attaches to
+	netAttachDefL2DomainIndex = "l2domain"
+	// netAttachDefNameIndex indexes NADs by name, regardless of namespace
+	netAttachDefNameIndex = "name"
+)
+
+var (
+	netAttachDefClient   netattachdefClientset.Interface
+	netAttachDefInformer cache.SharedIndexInformer
+	// netAttachDefIndexer is the NAD cache the cluster-wide checks read;
+	// checks are skipped while it is nil or not synced yet
+	netAttachDefIndexer cache.Indexer
+	netAttachDefSynced  cache.InformerSynced
+
+	networkPodLister NetworkPodLister
+
+	namespaceInformer cache.SharedIndexInformer
+	// namespaceIndexer is the namespace cache the per-namespace modes are read
+	// from; the cluster-wide modes apply while it is nil or not synced yet
+	namespaceIndexer cache.Indexer
+	namespaceSynced  cache.InformerSynced
+)
+
+// NetworkPodLister returns the running pods whose networks annotation refers
+// to the NAD namespace/name, and false while its pod cache is not ready
+type NetworkPodLister func(namespace, name string) ([]*v1.Pod, bool, error)
+
+// SetNetworkPodLister sets the pod cache the checks involving running pods
+// read; these checks are skipped until it is set
+func SetNetworkPodLister(lister NetworkPodLister) {
+	networkPodLister = lister
+}
+
+// podsUsingNetwork returns the running pods referring to the NAD
+// namespace/name, and false if the pod cache cannot be used
+func podsUsingNetwork(namespace, name string) ([]*v1.Pod, bool, error) {
+	if networkPodLister == nil {
+		return nil, false, nil
+	}
+	return networkPodLister(namespace, name)
+}
+
+// setupNetAttachDefInformer creates the NAD informer from the NAD clientset
+func setupNetAttachDefInformer() {
+	netAttachDefInformer = cache.NewSharedIndexInformer(
+		cache.NewListWatchFromClient(
+			netAttachDefClient.K8sCniCncfIoV1().RESTClient(),
+			"network-attachment-definitions", v1.NamespaceAll, fields.Everything(),
+		),
+		&netv1.NetworkAttachmentDefinition{},
+		informerResyncPeriod,
+		netAttachDefIndexers(),
+	)
+	netAttachDefIndexer = netAttachDefInformer.GetIndexer()
+	netAttachDefSynced = netAttachDefInformer.HasSynced
+}
+
+// setupNamespaceInformer creates the namespace informer from the clientset
+func setupNamespaceInformer() {
+	namespaceInformer = cache.NewSharedIndexInformer(
+		cache.NewListWatchFromClient(
+			clientset.CoreV1().RESTClient(),
+			"namespaces", v1.NamespaceAll, fields.Everything(),
+		),
+		&v1.Namespace{},
+		informerResyncPeriod,
+		cache.Indexers{},
+	)
+	namespaceIndexer = namespaceInformer.GetIndexer()
+	namespaceSynced = namespaceInformer.HasSynced
+}
+
+// netAttachDefIndexers returns the indexes of the NAD cache
+func netAttachDefIndexers() cache.Indexers {
+	return cache.Indexers{
+		cache.NamespaceIndex:      cache.MetaNamespaceIndexFunc,
+		netAttachDefL2DomainIndex: netAttachDefL2DomainIndexFunc,
+		netAttachDefNameIndex:     netAttachDefNameIndexFunc,
+	}
+}
+
+// StartInformers runs the informers backing the cluster-wide checks until
+// stopCh is closed
+func StartInformers(stopCh <-chan struct{}) {
+	if namespaceInformer != nil {
+		go namespaceInformer.Run(stopCh)
+	}
+	if grantInformer != nil {
+		go grantInformer.Run(stopCh)
+	}
+	if pluginPolicyInformer != nil {
+		go pluginPolicyInformer.Run(stopCh)
+	}
+	if inventoryInformer != nil {
+		go inventoryInformer.Run(stopCh)
+	}
+	if netAttachDefInformer == nil {
+		return
+	}
+	go netAttachDefInformer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, netAttachDefSynced) {
+		glog.Error("timed out waiting for net-attach-def cache to sync")
+		return
+	}
+	glog.Info("net-attach-def cache synced")
+}
+
+// netAttachDefCacheReady reports whether the NAD cache can be used
+func netAttachDefCacheReady() bool {
+	if netAttachDefIndexer == nil {
+		return false
+	}
+	return netAttachDefSynced == nil || netAttachDefSynced()
+}
+
+// getNetAttachDef looks a NAD up in the NAD cache. As the cache may lag
+// behind a NAD created right before the pod, misses are confirmed against the
+// API server. It returns nil if the NAD does not exist.
+func getNetAttachDef(namespace, name string) (*netv1.NetworkAttachmentDefinition, error) {
+	obj, exists, err := netAttachDefIndexer.GetByKey(namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return obj.(*netv1.NetworkAttachmentDefinition), nil
+	}
+	if netAttachDefClient == nil {
+		return nil, nil
+	}
+
+	netAttachDef, err := netAttachDefClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return netAttachDef, nil
+}
+
+// getNamespace looks a namespace up in the namespace cache. It returns nil if
+// the cache is not ready or the namespace is not known.
+func getNamespace(name string) *v1.Namespace {
+	if namespaceIndexer == nil || (namespaceSynced != nil && !namespaceSynced()) {
+		return nil
+	}
+	obj, exists, err := namespaceIndexer.GetByKey(name)
+	if err != nil || !exists {
+		return nil
+	}
+	namespace, _ := obj.(*v1.Namespace)
+	return namespace
+}
+
+func netAttachDefNameIndexFunc(obj interface{}) ([]string, error) {
+	netAttachDef, ok := obj.(*netv1.NetworkAttachmentDefinition)
+	if !ok {
+		return nil, nil
+	}
+	return []string{netAttachDef.Name}, nil
+}
+
+func netAttachDefL2DomainIndexFunc(obj interface{}) ([]string, error) {
+	netAttachDef, ok := obj.(*netv1.NetworkAttachmentDefinition)
+	if !ok || netAttachDef.Spec.Config == "" {
+		return nil, nil
+	}
+	domain := getL2Domain([]byte(netAttachDef.Spec.Config))
+	if domain == "" {
+		return nil, nil
+	}
+	return []string{domain}, nil
+}
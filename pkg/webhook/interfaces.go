@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+)
+
+// maxInterfaceNameLength is IFNAMSIZ minus the terminating NUL byte
+const maxInterfaceNameLength = 15
+
+// reservedInterfaceNames are the interfaces every pod already has: the
+// loopback and the interface of the cluster default network
+var reservedInterfaceNames = map[string]struct{}{
+	"lo":   {},
+	"eth0": {},
+}
+
+// requestedInterfaceName returns the interface name requested for an
+// attachment, if any
+func requestedInterfaceName(network *types.NetworkSelectionElement) string {
+	if network.InterfaceRequest != "" {
+		return network.InterfaceRequest
+	}
+	return network.DeprecatedInterfaceRequest
+}
+
+// defaultInterfaceName returns the name Multus gives the interface of the
+// attachment at index when none is requested
+func defaultInterfaceName(index int) string {
+	return fmt.Sprintf("net%d", index+1)
+}
+
+// validateInterfaceName checks name is a valid Linux interface name
+func validateInterfaceName(name string) error {
+	if len(name) > maxInterfaceNameLength {
+		return errors.Errorf("interface name '%s' is longer than %d bytes", name, maxInterfaceNameLength)
+	}
+	if name == "." || name == ".." {
+		return errors.Errorf("interface name '%s' is not allowed", name)
+	}
+	for _, c := range name {
+		if c <= ' ' || c > '~' || c == '/' || c == ':' {
+			return errors.Errorf("interface name '%s' must only contain printable ASCII characters other than '/', ':' and whitespace", name)
+		}
+	}
+	if _, ok := reservedInterfaceNames[name]; ok {
+		return errors.Errorf("interface name '%s' is reserved", name)
+	}
+	return nil
+}
+
+// validateInterfaceRequests checks the interface names requested by all the
+// attachments of a pod, along with the ones Multus assigns by default, are
+// valid and do not conflict with each other
+func validateInterfaceRequests(networks []*types.NetworkSelectionElement, podNamespace string) error {
+	var problems []string
+	describe := func(i int) string {
+		return describeAttachment(networks, i, podNamespace)
+	}
+
+	owners := map[string]int{}
+	defaulted := map[int]bool{}
+	for i, network := range networks {
+		name := requestedInterfaceName(network)
+		if name == "" {
+			name = defaultInterfaceName(i)
+			defaulted[i] = true
+		} else if err := validateInterfaceName(name); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", describe(i), err))
+			continue
+		}
+		if owner, ok := owners[name]; ok {
+			problem := fmt.Sprintf("%s: interface name '%s' is already used by %s", describe(i), name, describe(owner))
+			if defaulted[i] {
+				problem = fmt.Sprintf("%s: default interface name '%s' is already used by %s", describe(i), name, describe(owner))
+			} else if defaulted[owner] {
+				problem += " by default"
+			}
+			problems = append(problems, problem)
+			continue
+		}
+		owners[name] = i
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.Errorf("%s annotation requests invalid interfaces: %s", networksAnnotationKey, strings.Join(problems, "; "))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interface request validation", func() {
+
+	DescribeTable("pod interface requests",
+		func(annotation string, message string) {
+			networks, err := parsePodNetworkAnnotation(annotation, namespaceConstraint)
+			Expect(err).NotTo(HaveOccurred())
+			err = validateInterfaceRequests(networks, "default")
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			}
+		},
+		Entry("default names", "net-a,net-b", ""),
+		Entry("requested names", "net-a@data0,net-b@data1", ""),
+		Entry("too long name", "net-a@averyveryverylongname", "attachment 1 (default/net-a): interface name 'averyveryverylongname' is longer than 15 bytes"),
+		Entry("too long name in JSON form", `[{"name": "net-a", "interface": "0123456789abcdef"}]`, "longer than 15 bytes"),
+		Entry("invalid characters in JSON form", `[{"name": "net-a", "interface": "data 0"}]`, "must only contain printable ASCII characters"),
+		Entry("deprecated interface request", `[{"name": "net-a", "interfaceRequest": "data/0"}]`, "must only contain printable ASCII characters"),
+		Entry("reserved name", "net-a@eth0", "interface name 'eth0' is reserved"),
+		Entry("loopback", `[{"name": "net-a", "interface": "lo"}]`, "interface name 'lo' is reserved"),
+		Entry("duplicate names", "net-a@data0,net-b@data0", "attachment 2 (default/net-b): interface name 'data0' is already used by attachment 1 (default/net-a)"),
+		Entry("name clashing with a later default name", "net-a@net2,net-b", "attachment 2 (default/net-b): default interface name 'net2' is already used by attachment 1 (default/net-a)"),
+		Entry("name clashing with an earlier default name", "net-a,net-b@net1", "interface name 'net1' is already used by attachment 1 (default/net-a) by default"),
+		Entry("same network twice", "net-a,net-a", ""),
+	)
+
+	It("should reject the pod with every problem", func() {
+		allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{
+			networksAnnotationKey: `[{"name": "net-a", "interface": "eth0"}, {"name": "net-b", "interface": "averyveryverylongname"}]`,
+		}))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("attachment 1"))
+		Expect(err.Error()).To(ContainSubstring("attachment 2"))
+	})
+})
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	"github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/inventory"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// nodeInterfaceFields are the fields naming the node interface a plugin type
+// attaches to
+var nodeInterfaceFields = map[string]string{
+	"macvlan":     "master",
+	"ipvlan":      "master",
+	"host-device": "device",
+}
+
+// DefaultInventoryAgents is the service account, as namespace/name, of the
+// inventory agent deployed by deployments/agent.yaml
+const DefaultInventoryAgents = "kube-system/net-attach-def-admission-controller-agent-sa"
+
+// nodeNameUserExtraKey is the user extra the API server sets to the node of
+// the pod a service account token is bound to
+const nodeNameUserExtraKey = "authentication.kubernetes.io/node-name"
+
+var (
+	inventoryInformer cache.SharedIndexInformer
+	// inventoryIndexer is the NodeNetworkInventory cache; the inventory
+	// checks are skipped while it is nil, not synced yet or empty
+	inventoryIndexer cache.Indexer
+	inventorySynced  cache.InformerSynced
+
+	inventoryPolicy = PolicyWarn
+
+	// inventoryAgents are the usernames of the service accounts of the
+	// inventory agent, which may only write the inventory of their node
+	inventoryAgents = mustParseInventoryAgents(DefaultInventoryAgents)
+)
+
+// SetInventoryAgents sets the service accounts of the inventory agent, given
+// as a comma separated list of namespace/name
+func SetInventoryAgents(agents string) error {
+	parsed, err := parseServiceAccounts("inventory agent", agents)
+	if err != nil {
+		return err
+	}
+	inventoryAgents = parsed
+	return nil
+}
+
+func mustParseInventoryAgents(agents string) map[string]bool {
+	parsed, err := parseServiceAccounts("inventory agent", agents)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// SetInventoryPolicy sets how NADs not matching the network inventory of
+// any node are handled
+func SetInventoryPolicy(policy string) error {
+	p, err := parsePolicy("inventory", policy)
+	if err != nil {
+		return err
+	}
+	inventoryPolicy = p
+	return nil
+}
+
+// setupInventoryInformer creates the NodeNetworkInventory informer
+func setupInventoryInformer(client rest.Interface) {
+	inventoryInformer = cache.NewSharedIndexInformer(
+		cache.NewListWatchFromClient(client, inventory.Resource, metav1.NamespaceAll, fields.Everything()),
+		&admissionv1alpha1.NodeNetworkInventory{},
+		informerResyncPeriod,
+		cache.Indexers{},
+	)
+	inventoryIndexer = inventoryInformer.GetIndexer()
+	inventorySynced = inventoryInformer.HasSynced
+}
+
+// nodeInventories returns the network inventories of the nodes sorted by
+// node name, or nil if the inventory cache cannot be used
+func nodeInventories() []*admissionv1alpha1.NodeNetworkInventory {
+	if inventoryIndexer == nil {
+		return nil
+	}
+	if inventorySynced != nil && !inventorySynced() {
+		glog.Warning("node network inventory cache is not ready, skipping inventory checks")
+		return nil
+	}
+	var inventories []*admissionv1alpha1.NodeNetworkInventory
+	for _, obj := range inventoryIndexer.List() {
+		if inv, ok := obj.(*admissionv1alpha1.NodeNetworkInventory); ok {
+			inventories = append(inventories, inv)
+		}
+	}
+	sort.Slice(inventories, func(i, j int) bool { return inventories[i].Name < inventories[j].Name })
+	return inventories
+}
+
+// findNodeInterface returns the interface of a node inventory with the given
+// name, nil if the node has none
+func findNodeInterface(inv *admissionv1alpha1.NodeNetworkInventory, name string) *admissionv1alpha1.NodeInterface {
+	for i := range inv.Spec.Interfaces {
+		if inv.Spec.Interfaces[i].Name == name {
+			return &inv.Spec.Interfaces[i]
+		}
+	}
+	return nil
+}
+
+// checkNodeInventory verifies the node interfaces the plugins of a NAD config
+// attach to, the master of macvlan and ipvlan and the device of host-device,
+// exist on some node, and that the mtu of macvlan and ipvlan does not exceed
+// the MTU of their master. Depending on the inventory policy the failures
+// are returned as an error or as warnings.
+func checkNodeInventory(confBytes []byte) ([]string, error) {
+	if inventoryPolicy == PolicyIgnore {
+		return nil, nil
+	}
+	inventories := nodeInventories()
+	if len(inventories) == 0 {
+		return nil, nil
+	}
+	plugins, err := getPluginConfs(confBytes)
+	if err != nil {
+		return nil, nil
+	}
+
+	var failures []string
+	for _, plugin := range plugins {
+		key, ok := nodeInterfaceFields[plugin.Type]
+		if !ok {
+			continue
+		}
+		name, _ := plugin.Raw[key].(string)
+		if name == "" {
+			continue
+		}
+		mtu, _ := plugin.Raw["mtu"].(float64)
+
+		found := false
+		var smaller []string
+		for _, inv := range inventories {
+			iface := findNodeInterface(inv, name)
+			if iface == nil {
+				continue
+			}
+			found = true
+			if key == "master" && int(mtu) > iface.MTU {
+				smaller = append(smaller, fmt.Sprintf("%s (%d)", inv.Name, iface.MTU))
+			}
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf("%s: interface %s exists on no node", plugin.Path.Child(key), name))
+		} else if len(smaller) > 0 {
+			failures = append(failures, fmt.Sprintf("%s: mtu %d exceeds the MTU of master %s on nodes %s",
+				plugin.Path.Child("mtu"), int(mtu), name, strings.Join(smaller, ", ")))
+		}
+	}
+	if len(failures) == 0 {
+		return nil, nil
+	}
+
+	msg := fmt.Sprintf("net-attach-def does not match the network inventory of the nodes: %s", strings.Join(failures, ", "))
+	glog.Info(msg)
+	return applyPolicy(inventoryPolicy, msg)
+}
+
+// checkNodeConfigFile verifies some node has a CNI config file for the
+// network of a NAD without spec.config, which Multus looks up by the name of
+// the NAD. Depending on the inventory policy the failure is returned as an
+// error or as a warning.
+func checkNodeConfigFile(name string) ([]string, error) {
+	if inventoryPolicy == PolicyIgnore {
+		return nil, nil
+	}
+	inventories := nodeInventories()
+	if len(inventories) == 0 {
+		return nil, nil
+	}
+	for _, inv := range inventories {
+		for _, config := range inv.Spec.CNIConfigs {
+			if config.Name == name {
+				return nil, nil
+			}
+		}
+	}
+
+	msg := fmt.Sprintf("net-attach-def has no spec.config and no node has a CNI config file for network %s", name)
+	glog.Info(msg)
+	return applyPolicy(inventoryPolicy, msg)
+}
+
+// analyzeInventoryWrite denies the inventory agents writing the network
+// inventory of another node than the one of the pod their token is bound to,
+// so that the agent of a compromised node cannot rewrite the inventories of
+// the others
+func analyzeInventoryWrite(ar *admissionv1.AdmissionReview) (bool, error) {
+	req := ar.Request
+	if !inventoryAgents[req.UserInfo.Username] {
+		return true, nil
+	}
+
+	var inv metav1.PartialObjectMetadata
+	if err := json.Unmarshal(req.Object.Raw, &inv); err != nil {
+		glog.Errorf("Could not unmarshal raw object: %v", err)
+		return false, err
+	}
+	nodes := req.UserInfo.Extra[nodeNameUserExtraKey]
+	if len(nodes) != 1 || nodes[0] == "" {
+		err := errors.Errorf("inventory agent %s may only write the network inventory of its node, and its token is not bound to a node", req.UserInfo.Username)
+		glog.Info(err)
+		return false, err
+	}
+	if inv.Name != nodes[0] {
+		err := errors.Errorf("inventory agent %s runs on node %s and may not write the network inventory of node %s", req.UserInfo.Username, nodes[0], inv.Name)
+		glog.Info(err)
+		return false, err
+	}
+	return true, nil
+}
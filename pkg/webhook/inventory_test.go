@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// useInventories replaces the NodeNetworkInventory cache with one holding
+// the given inventories
+func useInventories(inventories ...*admissionv1alpha1.NodeNetworkInventory) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, inv := range inventories {
+		Expect(indexer.Add(inv)).To(Succeed())
+	}
+	inventoryIndexer = indexer
+	inventorySynced = nil
+}
+
+// nodeInventory returns the inventory of a node with the given interfaces
+// and CNI config files
+func nodeInventory(node string, interfaces []admissionv1alpha1.NodeInterface, configs ...string) *admissionv1alpha1.NodeNetworkInventory {
+	inv := &admissionv1alpha1.NodeNetworkInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: node},
+		Spec:       admissionv1alpha1.NodeNetworkInventorySpec{Interfaces: interfaces},
+	}
+	for _, name := range configs {
+		inv.Spec.CNIConfigs = append(inv.Spec.CNIConfigs, admissionv1alpha1.CNIConfigFile{Path: "/etc/cni/multus/net.d/" + name + ".conf", Name: name})
+	}
+	return inv
+}
+
+var _ = Describe("Node network inventory checks", func() {
+
+	BeforeEach(func() {
+		useInventories(
+			nodeInventory("node1", []admissionv1alpha1.NodeInterface{{Name: "eth0", MTU: 1500}, {Name: "eth1", MTU: 9000, SRIOVTotalVFs: 8}}, "storage"),
+			nodeInventory("node2", []admissionv1alpha1.NodeInterface{{Name: "eth0", MTU: 1500}, {Name: "eth1", MTU: 1500}, {Name: "ens5", MTU: 1500}}),
+		)
+		Expect(SetInventoryPolicy(PolicyDeny)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		inventoryIndexer = nil
+		inventoryPolicy = PolicyWarn
+	})
+
+	DescribeTable("net-attach-def interfaces",
+		func(config string, expectedErr string) {
+			_, err := checkNodeInventory([]byte(config))
+			if expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("existing master", `{"cniVersion": "1.0.0", "type": "macvlan", "master": "eth1"}`, ""),
+		Entry("master of some nodes", `{"cniVersion": "1.0.0", "type": "ipvlan", "master": "ens5"}`, ""),
+		Entry("default master", `{"cniVersion": "1.0.0", "type": "macvlan"}`, ""),
+		Entry("missing master", `{"cniVersion": "1.0.0", "type": "macvlan", "master": "eth7"}`,
+			"net-attach-def does not match the network inventory of the nodes: spec.config.master: interface eth7 exists on no node"),
+		Entry("missing host device", `{"cniVersion": "1.0.0", "name": "n", "plugins": [{"type": "host-device", "device": "ens9"}]}`,
+			"spec.config.plugins[0].device: interface ens9 exists on no node"),
+		Entry("existing host device", `{"cniVersion": "1.0.0", "type": "host-device", "device": "ens5"}`, ""),
+		Entry("mtu within the master MTU", `{"cniVersion": "1.0.0", "type": "macvlan", "master": "eth1", "mtu": 1500}`, ""),
+		Entry("mtu exceeding the master MTU on some nodes", `{"cniVersion": "1.0.0", "type": "macvlan", "master": "eth1", "mtu": 9000}`,
+			"spec.config.mtu: mtu 9000 exceeds the MTU of master eth1 on nodes node2 (1500)"),
+		Entry("mtu exceeding the master MTU on every node", `{"cniVersion": "1.0.0", "type": "ipvlan", "master": "eth0", "mtu": 9000}`,
+			"spec.config.mtu: mtu 9000 exceeds the MTU of master eth0 on nodes node1 (1500), node2 (1500)"),
+		Entry("other plugin types", `{"cniVersion": "1.0.0", "type": "bridge", "bridge": "br7", "mtu": 9000}`, ""),
+	)
+
+	It("should check a CNI config file exists for net-attach-defs without config", func() {
+		_, err := checkNodeConfigFile("storage")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = checkNodeConfigFile("backup")
+		Expect(err).To(MatchError("net-attach-def has no spec.config and no node has a CNI config file for network backup"))
+	})
+
+	It("should warn by default", func() {
+		inventoryPolicy = PolicyWarn
+		allowed, warnings, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "backup", ""), authenticationv1.UserInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(warnings).To(ConsistOf("net-attach-def has no spec.config and no node has a CNI config file for network backup"))
+
+		allowed, warnings, err = validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net",
+			`{"cniVersion": "1.0.0", "type": "macvlan", "master": "eth7"}`), authenticationv1.UserInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(warnings).To(ContainElement(ContainSubstring("interface eth7 exists on no node")))
+	})
+
+	It("should skip the checks without inventories", func() {
+		useInventories()
+		_, err := checkNodeInventory([]byte(`{"cniVersion": "1.0.0", "type": "macvlan", "master": "eth7"}`))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = checkNodeConfigFile("backup")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should skip the checks while the inventories are not synced", func() {
+		inventorySynced = func() bool { return false }
+		_, err := checkNodeInventory([]byte(`{"cniVersion": "1.0.0", "type": "macvlan", "master": "eth7"}`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+
+const agentUsername = "system:serviceaccount:kube-system:net-attach-def-admission-controller-agent-sa"
+
+// newInventoryAdmissionReview returns the review of a request writing the
+// inventory of a node, by a user whose token is bound to a pod of boundNode
+func newInventoryAdmissionReview(username, boundNode, node string) *admissionv1.AdmissionReview {
+	raw, err := json.Marshal(nodeInventory(node, nil))
+	Expect(err).NotTo(HaveOccurred())
+	userInfo := authenticationv1.UserInfo{Username: username}
+	if boundNode != "" {
+		userInfo.Extra = map[string]authenticationv1.ExtraValue{nodeNameUserExtraKey: {boundNode}}
+	}
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "fake-uid",
+			Kind:      metav1.GroupVersionKind{Group: "admission.k8s.cni.cncf.io", Version: "v1alpha1", Kind: "NodeNetworkInventory"},
+			Name:      node,
+			Operation: admissionv1.Update,
+			UserInfo:  userInfo,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+var _ = Describe("Node network inventory writes", func() {
+
+	AfterEach(func() {
+		inventoryAgents = mustParseInventoryAgents(DefaultInventoryAgents)
+	})
+
+	DescribeTable("inventory agents",
+		func(username, boundNode, node string, message string) {
+			allowed, err := analyzeInventoryWrite(newInventoryAdmissionReview(username, boundNode, node))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("agent writing the inventory of its node", agentUsername, "node1", "node1", ""),
+		Entry("agent writing the inventory of another node", agentUsername, "node1", "node2",
+			"inventory agent system:serviceaccount:kube-system:net-attach-def-admission-controller-agent-sa runs on node node1 and may not write the network inventory of node node2"),
+		Entry("agent token not bound to a node", agentUsername, "", "node1",
+			"may only write the network inventory of its node, and its token is not bound to a node"),
+		Entry("other users", "alice", "", "node2", ""),
+	)
+
+	It("should restrict the configured service accounts", func() {
+		Expect(SetInventoryAgents("network/agent")).To(Succeed())
+		allowed, err := analyzeInventoryWrite(newInventoryAdmissionReview(agentUsername, "node1", "node2"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+
+		allowed, err = analyzeInventoryWrite(newInventoryAdmissionReview("system:serviceaccount:network:agent", "node1", "node2"))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("runs on node node1 and may not write the network inventory of node node2")))
+
+		Expect(SetInventoryAgents("")).To(Succeed())
+		Expect(inventoryAgents).To(BeEmpty())
+		Expect(SetInventoryAgents("agent")).To(MatchError("invalid inventory agent 'agent', must be a service account in the form namespace/name"))
+	})
+})
@@ -0,0 +1,379 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type ipamRouteConf struct {
+	Dst string `json:"dst"`
+	GW  string `json:"gw,omitempty"`
+}
+
+type ipamDNSConf struct {
+	Nameservers []string `json:"nameservers,omitempty"`
+	Domain      string   `json:"domain,omitempty"`
+	Search      []string `json:"search,omitempty"`
+	Options     []string `json:"options,omitempty"`
+}
+
+type hostLocalRangeConf struct {
+	Subnet     string `json:"subnet"`
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+	Gateway    string `json:"gateway,omitempty"`
+}
+
+type hostLocalIPAMConf struct {
+	hostLocalRangeConf
+	Type       string                 `json:"type"`
+	Ranges     [][]hostLocalRangeConf `json:"ranges,omitempty"`
+	Routes     []ipamRouteConf        `json:"routes,omitempty"`
+	DataDir    string                 `json:"dataDir,omitempty"`
+	ResolvConf string                 `json:"resolvConf,omitempty"`
+}
+
+type staticAddressConf struct {
+	Address string `json:"address"`
+	Gateway string `json:"gateway,omitempty"`
+}
+
+type staticIPAMConf struct {
+	Type      string              `json:"type"`
+	Addresses []staticAddressConf `json:"addresses,omitempty"`
+	Routes    []ipamRouteConf     `json:"routes,omitempty"`
+	DNS       *ipamDNSConf        `json:"dns,omitempty"`
+}
+
+type dhcpOptionConf struct {
+	Option      string `json:"option"`
+	Value       string `json:"value,omitempty"`
+	FromArg     string `json:"fromArg,omitempty"`
+	SkipDefault bool   `json:"skipDefault,omitempty"`
+}
+
+type dhcpIPAMConf struct {
+	Type             string           `json:"type"`
+	DaemonSocketPath string           `json:"daemonSocketPath,omitempty"`
+	Request          []dhcpOptionConf `json:"request,omitempty"`
+	Provide          []dhcpOptionConf `json:"provide,omitempty"`
+	Broadcast        bool             `json:"broadcast,omitempty"`
+	Timeout          int              `json:"timeout,omitempty"`
+	ResendMax        int              `json:"resendMax,omitempty"`
+}
+
+// ipamRange is a parsed host-local range
+type ipamRange struct {
+	Subnet     *net.IPNet
+	RangeStart net.IP
+	RangeEnd   net.IP
+	Gateway    net.IP
+}
+
+// ipamValidator validates the IPAM block of a plugin of a given IPAM type
+type ipamValidator func(ipam map[string]interface{}, path *field.Path) field.ErrorList
+
+// ipamValidators maps an IPAM 'type' to its validator; other IPAM types are
+// not checked
+var ipamValidators = map[string]ipamValidator{
+	"host-local": validateHostLocalIPAMConf,
+	"static":     validateStaticIPAMConf,
+	"dhcp":       validateDHCPIPAMConf,
+}
+
+// validateIPAMConf validates the 'ipam' object of a plugin configuration
+func validateIPAMConf(ipam interface{}, path *field.Path) field.ErrorList {
+	raw, ok := ipam.(map[string]interface{})
+	if !ok {
+		return field.ErrorList{field.Invalid(path, ipam, "must be an object")}
+	}
+	t, _ := raw["type"].(string)
+	if t == "" {
+		return field.ErrorList{field.Required(path.Child("type"), "IPAM type must be set")}
+	}
+	if validator, ok := ipamValidators[t]; ok {
+		return validator(raw, path)
+	}
+	return nil
+}
+
+func validateHostLocalIPAMConf(ipam map[string]interface{}, path *field.Path) field.ErrorList {
+	conf := &hostLocalIPAMConf{}
+	allErrs := decodeConf(ipam, path, "IPAM type 'host-local'", conf)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	var rangeSets [][]*ipamRange
+	var setPaths []*field.Path
+	if conf.Subnet != "" {
+		r, errs := parseHostLocalRange(conf.hostLocalRangeConf, path)
+		allErrs = append(allErrs, errs...)
+		if r != nil {
+			rangeSets = append(rangeSets, []*ipamRange{r})
+			setPaths = append(setPaths, path)
+		}
+	} else if conf.RangeStart != "" || conf.RangeEnd != "" || conf.Gateway != "" {
+		allErrs = append(allErrs, field.Required(path.Child("subnet"), "must be set together with 'rangeStart', 'rangeEnd' or 'gateway'"))
+	}
+
+	for i, rangeSet := range conf.Ranges {
+		setPath := path.Child("ranges").Index(i)
+		if len(rangeSet) == 0 {
+			allErrs = append(allErrs, field.Required(setPath, "range set must not be empty"))
+			continue
+		}
+		var parsed []*ipamRange
+		for j, rc := range rangeSet {
+			rangePath := setPath.Index(j)
+			r, errs := parseHostLocalRange(rc, rangePath)
+			allErrs = append(allErrs, errs...)
+			if r == nil {
+				continue
+			}
+			for k, other := range parsed {
+				if isIPv4(other.Subnet.IP) != isIPv4(r.Subnet.IP) {
+					allErrs = append(allErrs, field.Invalid(rangePath.Child("subnet"), rc.Subnet, fmt.Sprintf("mixes address families with %s within the same range set", setPath.Index(k))))
+				} else if rangesOverlap(other, r) {
+					allErrs = append(allErrs, field.Invalid(rangePath, rc.Subnet, fmt.Sprintf("overlaps with %s", setPath.Index(k))))
+				}
+			}
+			parsed = append(parsed, r)
+		}
+		rangeSets = append(rangeSets, parsed)
+		setPaths = append(setPaths, setPath)
+	}
+
+	if conf.Subnet == "" && len(conf.Ranges) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("ranges"), "either 'subnet' or 'ranges' must be set"))
+	}
+
+	for i := range rangeSets {
+		for j := i + 1; j < len(rangeSets); j++ {
+			if rangeSetsOverlap(rangeSets[i], rangeSets[j]) {
+				allErrs = append(allErrs, field.Forbidden(setPaths[j], fmt.Sprintf("overlaps with the range set at %s", setPaths[i])))
+			}
+		}
+	}
+
+	allErrs = append(allErrs, validateIPAMRoutes(conf.Routes, path.Child("routes"))...)
+	return allErrs
+}
+
+// parseHostLocalRange parses a host-local range and checks its addresses
+// belong to its subnet and are in order
+func parseHostLocalRange(rc hostLocalRangeConf, path *field.Path) (*ipamRange, field.ErrorList) {
+	allErrs := field.ErrorList{}
+
+	if rc.Subnet == "" {
+		return nil, append(allErrs, field.Required(path.Child("subnet"), ""))
+	}
+	ip, subnet, err := net.ParseCIDR(rc.Subnet)
+	if err != nil {
+		return nil, append(allErrs, field.Invalid(path.Child("subnet"), rc.Subnet, "must be a valid CIDR"))
+	}
+	if !ip.Equal(subnet.IP) {
+		allErrs = append(allErrs, field.Invalid(path.Child("subnet"), rc.Subnet, fmt.Sprintf("has host bits set, did you mean %s?", subnet.String())))
+	}
+	if ones, bits := subnet.Mask.Size(); bits-ones < 2 {
+		allErrs = append(allErrs, field.Invalid(path.Child("subnet"), rc.Subnet, "is too small to allocate addresses from"))
+	}
+
+	r := &ipamRange{Subnet: subnet}
+	r.RangeStart, allErrs = parseIPInSubnet(rc.RangeStart, subnet, path.Child("rangeStart"), allErrs)
+	r.RangeEnd, allErrs = parseIPInSubnet(rc.RangeEnd, subnet, path.Child("rangeEnd"), allErrs)
+	r.Gateway, allErrs = parseIPInSubnet(rc.Gateway, subnet, path.Child("gateway"), allErrs)
+
+	if r.RangeStart != nil && r.RangeEnd != nil && bytes.Compare(r.RangeStart.To16(), r.RangeEnd.To16()) > 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("rangeStart"), rc.RangeStart, fmt.Sprintf("must not be after rangeEnd %s", rc.RangeEnd)))
+	}
+	return r, allErrs
+}
+
+func parseIPInSubnet(s string, subnet *net.IPNet, path *field.Path, allErrs field.ErrorList) (net.IP, field.ErrorList) {
+	if s == "" {
+		return nil, allErrs
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, append(allErrs, field.Invalid(path, s, "must be a valid IP address"))
+	}
+	if !subnet.Contains(ip) {
+		return nil, append(allErrs, field.Invalid(path, s, fmt.Sprintf("must be within subnet %s", subnet.String())))
+	}
+	return ip, allErrs
+}
+
+// firstIP returns the first address that can be allocated from the range
+func (r *ipamRange) firstIP() net.IP {
+	if r.RangeStart != nil {
+		return r.RangeStart
+	}
+	return r.Subnet.IP
+}
+
+// lastIP returns the last address that can be allocated from the range
+func (r *ipamRange) lastIP() net.IP {
+	if r.RangeEnd != nil {
+		return r.RangeEnd
+	}
+	ip := make(net.IP, len(r.Subnet.IP))
+	for i := range r.Subnet.IP {
+		ip[i] = r.Subnet.IP[i] | ^r.Subnet.Mask[i]
+	}
+	return ip
+}
+
+// contains reports whether ip can be allocated from the range
+func (r *ipamRange) contains(ip net.IP) bool {
+	if !r.Subnet.Contains(ip) {
+		return false
+	}
+	return bytes.Compare(ip.To16(), r.firstIP().To16()) >= 0 && bytes.Compare(ip.To16(), r.lastIP().To16()) <= 0
+}
+
+func rangesOverlap(a, b *ipamRange) bool {
+	if isIPv4(a.Subnet.IP) != isIPv4(b.Subnet.IP) {
+		return false
+	}
+	return a.contains(b.firstIP()) || a.contains(b.lastIP()) || b.contains(a.firstIP()) || b.contains(a.lastIP())
+}
+
+func rangeSetsOverlap(a, b []*ipamRange) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if rangesOverlap(ra, rb) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isIPv4(ip net.IP) bool {
+	return ip.To4() != nil
+}
+
+func validateIPAMRoutes(routes []ipamRouteConf, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, route := range routes {
+		routePath := path.Index(i)
+		if route.Dst == "" {
+			allErrs = append(allErrs, field.Required(routePath.Child("dst"), ""))
+			continue
+		}
+		ip, dst, err := net.ParseCIDR(route.Dst)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("dst"), route.Dst, "must be a valid CIDR"))
+			continue
+		}
+		// the kernel rejects IPv4 routes with host bits set but masks IPv6
+		// ones, which the examples of the reference plugins rely on
+		if !ip.Equal(dst.IP) && isIPv4(ip) {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("dst"), route.Dst, fmt.Sprintf("has host bits set, did you mean %s?", dst.String())))
+		}
+		if route.GW == "" {
+			continue
+		}
+		gw := net.ParseIP(route.GW)
+		if gw == nil {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("gw"), route.GW, "must be a valid IP address"))
+		} else if isIPv4(gw) != isIPv4(dst.IP) {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("gw"), route.GW, fmt.Sprintf("must be of the same address family as dst %s", route.Dst)))
+		}
+	}
+	return allErrs
+}
+
+func validateStaticIPAMConf(ipam map[string]interface{}, path *field.Path) field.ErrorList {
+	conf := &staticIPAMConf{}
+	allErrs := decodeConf(ipam, path, "IPAM type 'static'", conf)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	var addresses []*net.IPNet
+	for i, addr := range conf.Addresses {
+		addrPath := path.Child("addresses").Index(i)
+		if addr.Address == "" {
+			allErrs = append(allErrs, field.Required(addrPath.Child("address"), ""))
+			continue
+		}
+		ip, subnet, err := net.ParseCIDR(addr.Address)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(addrPath.Child("address"), addr.Address, "must be a valid IP address in CIDR notation"))
+			continue
+		}
+		for j, other := range addresses {
+			if other.IP.Equal(ip) {
+				allErrs = append(allErrs, field.Duplicate(addrPath.Child("address"), fmt.Sprintf("%s (same as %s)", addr.Address, path.Child("addresses").Index(j))))
+			}
+		}
+		addresses = append(addresses, &net.IPNet{IP: ip, Mask: subnet.Mask})
+		if addr.Gateway == "" {
+			continue
+		}
+		gw := net.ParseIP(addr.Gateway)
+		if gw == nil {
+			allErrs = append(allErrs, field.Invalid(addrPath.Child("gateway"), addr.Gateway, "must be a valid IP address"))
+		} else if gw.Equal(ip) {
+			allErrs = append(allErrs, field.Invalid(addrPath.Child("gateway"), addr.Gateway, "must differ from the address"))
+		}
+	}
+
+	allErrs = append(allErrs, validateIPAMRoutes(conf.Routes, path.Child("routes"))...)
+	if conf.DNS != nil {
+		for i, ns := range conf.DNS.Nameservers {
+			if net.ParseIP(ns) == nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("dns", "nameservers").Index(i), ns, "must be a valid IP address"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateDHCPIPAMConf(ipam map[string]interface{}, path *field.Path) field.ErrorList {
+	conf := &dhcpIPAMConf{}
+	allErrs := decodeConf(ipam, path, "IPAM type 'dhcp'", conf)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if conf.Timeout < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), conf.Timeout, "must not be negative"))
+	}
+	if conf.ResendMax < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("resendMax"), conf.ResendMax, "must not be negative"))
+	}
+	for i, opt := range conf.Request {
+		if opt.Option == "" {
+			allErrs = append(allErrs, field.Required(path.Child("request").Index(i).Child("option"), ""))
+		}
+	}
+	for i, opt := range conf.Provide {
+		if opt.Option == "" {
+			allErrs = append(allErrs, field.Required(path.Child("provide").Index(i).Child("option"), ""))
+		}
+		if opt.Value != "" && opt.FromArg != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("provide").Index(i), "only one of 'value' or 'fromArg' may be set"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("IPAM validation", func() {
+
+	DescribeTable("IPAM block validation",
+		func(ipam string, expectedErrs []string) {
+			var raw interface{}
+			Expect(json.Unmarshal([]byte(ipam), &raw)).To(Succeed())
+			errs := validateIPAMConf(raw, field.NewPath("spec", "config", "ipam"))
+			Expect(errs).To(HaveLen(len(expectedErrs)))
+			for i, e := range expectedErrs {
+				Expect(errs[i].Error()).To(ContainSubstring(e))
+			}
+		},
+		Entry("valid host-local",
+			`{"type": "host-local", "subnet": "192.168.1.0/24", "rangeStart": "192.168.1.200", "rangeEnd": "192.168.1.216", "gateway": "192.168.1.1", "routes": [{"dst": "0.0.0.0/0"}]}`,
+			[]string{},
+		),
+		Entry("valid dual-stack host-local ranges",
+			`{"type": "host-local", "ranges": [[{"subnet": "10.1.0.0/24"}, {"subnet": "10.1.1.0/24"}], [{"subnet": "fd00::/64"}]]}`,
+			[]string{},
+		),
+		Entry("misspelled subnet key",
+			`{"type": "host-local", "subnett": "192.168.1.0/24"}`,
+			[]string{"spec.config.ipam.subnett: Forbidden: unknown field"},
+		),
+		Entry("range start outside the subnet",
+			`{"type": "host-local", "subnet": "192.168.1.0/24", "rangeStart": "192.168.2.10"}`,
+			[]string{"spec.config.ipam.rangeStart: Invalid value: \"192.168.2.10\": must be within subnet 192.168.1.0/24"},
+		),
+		Entry("range start after range end",
+			`{"type": "host-local", "subnet": "192.168.1.0/24", "rangeStart": "192.168.1.100", "rangeEnd": "192.168.1.10"}`,
+			[]string{"spec.config.ipam.rangeStart: Invalid value"},
+		),
+		Entry("gateway in another prefix",
+			`{"type": "host-local", "ranges": [[{"subnet": "10.1.0.0/24", "gateway": "10.2.0.1"}]]}`,
+			[]string{"spec.config.ipam.ranges[0][0].gateway: Invalid value"},
+		),
+		Entry("subnet with host bits set",
+			`{"type": "host-local", "subnet": "10.1.0.5/24"}`,
+			[]string{"did you mean 10.1.0.0/24?"},
+		),
+		Entry("mixed families within one range set",
+			`{"type": "host-local", "ranges": [[{"subnet": "10.1.0.0/24"}, {"subnet": "fd00::/64"}]]}`,
+			[]string{"spec.config.ipam.ranges[0][1].subnet: Invalid value"},
+		),
+		Entry("overlapping range sets",
+			`{"type": "host-local", "ranges": [[{"subnet": "10.1.0.0/16"}], [{"subnet": "10.1.2.0/24"}]]}`,
+			[]string{"spec.config.ipam.ranges[1]: Forbidden: overlaps with the range set at spec.config.ipam.ranges[0]"},
+		),
+		Entry("host-local without any range",
+			`{"type": "host-local"}`,
+			[]string{"spec.config.ipam.ranges: Required value"},
+		),
+		Entry("malformed route destination",
+			`{"type": "host-local", "subnet": "10.1.0.0/24", "routes": [{"dst": "0.0.0.0"}, {"dst": "fd00::/64", "gw": "10.1.0.1"}]}`,
+			[]string{"spec.config.ipam.routes[0].dst: Invalid value", "spec.config.ipam.routes[1].gw: Invalid value"},
+		),
+		Entry("route destinations with host bits set",
+			`{"type": "host-local", "subnet": "10.1.0.0/24", "routes": [{"dst": "192.168.1.5/16"}, {"dst": "3ffe:ffff:0:01ff::1/64"}]}`,
+			[]string{"spec.config.ipam.routes[0].dst: Invalid value: \"192.168.1.5/16\": has host bits set, did you mean 192.168.0.0/16?"},
+		),
+		Entry("valid static",
+			`{"type": "static", "addresses": [{"address": "10.10.0.1/24", "gateway": "10.10.0.254"}], "routes": [{"dst": "0.0.0.0/0"}], "dns": {"nameservers": ["8.8.8.8"]}}`,
+			[]string{},
+		),
+		Entry("static with invalid address, gateway and nameserver",
+			`{"type": "static", "addresses": [{"address": "10.10.0.1"}, {"address": "10.10.0.2/24", "gateway": "10.10.0.256"}], "dns": {"nameservers": ["dns.example.com"]}}`,
+			[]string{"spec.config.ipam.addresses[0].address: Invalid value", "spec.config.ipam.addresses[1].gateway: Invalid value", "spec.config.ipam.dns.nameservers[0]: Invalid value"},
+		),
+		Entry("static gateway outside the subnet of its address",
+			`{"type": "static", "addresses": [{"address": "3ffe:ffff:0:01ff::1/64", "gateway": "3ffe:ffff:0::1"}]}`,
+			[]string{},
+		),
+		Entry("valid dhcp",
+			`{"type": "dhcp", "request": [{"option": "classless-static-routes"}]}`,
+			[]string{},
+		),
+		Entry("dhcp with subnet",
+			`{"type": "dhcp", "subnet": "10.1.0.0/24"}`,
+			[]string{"spec.config.ipam.subnet: Forbidden: unknown field for IPAM type 'dhcp'"},
+		),
+		Entry("unknown IPAM types are not checked",
+			`{"type": "whereabouts", "range": "10.1.0.0/24"}`,
+			[]string{},
+		),
+		Entry("missing IPAM type",
+			`{"subnet": "10.1.0.0/24"}`,
+			[]string{"spec.config.ipam.type: Required value"},
+		),
+	)
+
+	It("should report the IPAM field path in a conflist", func() {
+		errs := validatePluginConfs([]byte(`{"cniVersion": "0.3.1", "plugins": [{"type": "bridge", "ipam": {"type": "host-local", "subnet": "10.1.0.0/33"}}]}`))
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Field).To(Equal("spec.config.plugins[0].ipam.subnet"))
+	})
+})
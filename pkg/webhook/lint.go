@@ -0,0 +1,263 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/containernetworking/cni/pkg/version"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Severities of the lint rules
+const (
+	// LintSeverityError denies the NAD
+	LintSeverityError = "error"
+	// LintSeverityWarning admits the NAD with an admission warning
+	LintSeverityWarning = "warning"
+	// LintSeverityOff disables the rule
+	LintSeverityOff = "off"
+)
+
+// lintFinding is a best-practice issue found in a NAD config
+type lintFinding struct {
+	Path    *field.Path
+	Message string
+}
+
+// lintRule checks a NAD config for one best-practice issue
+type lintRule struct {
+	Name     string
+	Severity string
+	Check    func(conf map[string]interface{}, plugins []pluginConf) []lintFinding
+}
+
+// DefaultOverlayPluginTypes are the plugin types encapsulating traffic, which
+// therefore need an MTU below the one of the underlying network
+const DefaultOverlayPluginTypes = "calico,cilium-cni,ovn-k8s-cni-overlay,vxlan"
+
+// overlayPluginTypes are the plugin types the overlay-missing-mtu rule checks
+var overlayPluginTypes = parseOverlayPluginTypes(DefaultOverlayPluginTypes)
+
+// SetOverlayPluginTypes sets the plugin types the overlay-missing-mtu rule
+// checks, given as a comma separated list
+func SetOverlayPluginTypes(types string) {
+	overlayPluginTypes = parseOverlayPluginTypes(types)
+}
+
+func parseOverlayPluginTypes(types string) map[string]bool {
+	parsed := map[string]bool{}
+	for _, item := range strings.Split(types, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			parsed[item] = true
+		}
+	}
+	return parsed
+}
+
+// lintRules are the rules run on every NAD config, in order
+var lintRules = []*lintRule{
+	{Name: "missing-cni-version", Severity: LintSeverityWarning, Check: lintMissingCNIVersion},
+	{Name: "deprecated-cni-version", Severity: LintSeverityWarning, Check: lintDeprecatedCNIVersion},
+	{Name: "host-local-open-range", Severity: LintSeverityWarning, Check: lintHostLocalOpenRange},
+	{Name: "overlay-missing-mtu", Severity: LintSeverityWarning, Check: lintOverlayMissingMTU},
+	{Name: "static-gateway-outside-subnet", Severity: LintSeverityWarning, Check: lintStaticGatewayOutsideSubnet},
+}
+
+// SetLintSeverities overrides the severity of lint rules, given as a comma
+// separated list of rule=severity
+func SetLintSeverities(severities string) error {
+	overrides := map[*lintRule]string{}
+	for _, item := range strings.Split(severities, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("invalid lint severity '%s', must be in the form rule=severity", item)
+		}
+		rule := findLintRule(parts[0])
+		if rule == nil {
+			return errors.Errorf("unknown lint rule '%s', must be one of %s", parts[0], strings.Join(lintRuleNames(), ", "))
+		}
+		switch parts[1] {
+		case LintSeverityError, LintSeverityWarning, LintSeverityOff:
+		default:
+			return errors.Errorf("invalid severity '%s' of lint rule '%s', must be one of %s, %s or %s", parts[1], parts[0], LintSeverityError, LintSeverityWarning, LintSeverityOff)
+		}
+		overrides[rule] = parts[1]
+	}
+	for rule, severity := range overrides {
+		rule.Severity = severity
+	}
+	return nil
+}
+
+func findLintRule(name string) *lintRule {
+	for _, rule := range lintRules {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// lintNetworkAttachmentDefinition runs the lint rules on a NAD config. The
+// findings of warning rules are returned as warnings, the ones of error rules
+// as errors.
+func lintNetworkAttachmentDefinition(confBytes []byte) ([]string, field.ErrorList) {
+	var conf map[string]interface{}
+	if err := json.Unmarshal(confBytes, &conf); err != nil {
+		return nil, nil
+	}
+	plugins, err := getPluginConfs(confBytes)
+	if err != nil {
+		return nil, nil
+	}
+
+	var warnings []string
+	allErrs := field.ErrorList{}
+	for _, rule := range lintRules {
+		if rule.Severity == LintSeverityOff {
+			continue
+		}
+		for _, finding := range rule.Check(conf, plugins) {
+			msg := fmt.Sprintf("%s (%s)", finding.Message, rule.Name)
+			if rule.Severity == LintSeverityError {
+				allErrs = append(allErrs, field.Forbidden(finding.Path, msg))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s: %s", finding.Path, msg))
+			}
+		}
+	}
+	return warnings, allErrs
+}
+
+func lintMissingCNIVersion(conf map[string]interface{}, plugins []pluginConf) []lintFinding {
+	if v, _ := conf["cniVersion"].(string); v != "" {
+		return nil
+	}
+	if versions, _ := conf["cniVersions"].([]interface{}); len(versions) > 0 {
+		return nil
+	}
+	return []lintFinding{{
+		Path:    field.NewPath("spec", "config", "cniVersion"),
+		Message: "cniVersion is not set, plugins fall back to the oldest version they support",
+	}}
+}
+
+func lintDeprecatedCNIVersion(conf map[string]interface{}, plugins []pluginConf) []lintFinding {
+	v, _ := conf["cniVersion"].(string)
+	for _, legacy := range version.Legacy.SupportedVersions() {
+		if v == legacy {
+			return []lintFinding{{
+				Path:    field.NewPath("spec", "config", "cniVersion"),
+				Message: fmt.Sprintf("cniVersion %s is deprecated, use %s or later", v, DefaultCNIVersion),
+			}}
+		}
+	}
+	return nil
+}
+
+func lintHostLocalOpenRange(conf map[string]interface{}, plugins []pluginConf) []lintFinding {
+	var findings []lintFinding
+	for _, plugin := range plugins {
+		ipam, ok := plugin.Raw["ipam"].(map[string]interface{})
+		if !ok || ipam["type"] != "host-local" {
+			continue
+		}
+		path := plugin.Path.Child("ipam")
+		checkRange := func(r map[string]interface{}, path *field.Path) {
+			if subnet, _ := r["subnet"].(string); subnet == "" {
+				return
+			}
+			if end, _ := r["rangeEnd"].(string); end == "" {
+				findings = append(findings, lintFinding{
+					Path:    path.Child("rangeEnd"),
+					Message: "rangeEnd is not set, addresses are allocated up to the end of the subnet",
+				})
+			}
+		}
+		checkRange(ipam, path)
+		ranges, _ := ipam["ranges"].([]interface{})
+		for i, rangeSet := range ranges {
+			rs, _ := rangeSet.([]interface{})
+			for j, r := range rs {
+				if rc, ok := r.(map[string]interface{}); ok {
+					checkRange(rc, path.Child("ranges").Index(i).Index(j))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+func lintOverlayMissingMTU(conf map[string]interface{}, plugins []pluginConf) []lintFinding {
+	var findings []lintFinding
+	for _, plugin := range plugins {
+		if !overlayPluginTypes[plugin.Type] {
+			continue
+		}
+		if _, ok := plugin.Raw["mtu"]; !ok {
+			findings = append(findings, lintFinding{
+				Path:    plugin.Path.Child("mtu"),
+				Message: fmt.Sprintf("mtu is not set on %s overlay, encapsulated packets may exceed the MTU of the underlying network", plugin.Type),
+			})
+		}
+	}
+	return findings
+}
+
+func lintStaticGatewayOutsideSubnet(conf map[string]interface{}, plugins []pluginConf) []lintFinding {
+	var findings []lintFinding
+	for _, plugin := range plugins {
+		ipam, ok := plugin.Raw["ipam"].(map[string]interface{})
+		if !ok || ipam["type"] != "static" {
+			continue
+		}
+		addresses, _ := ipam["addresses"].([]interface{})
+		for i, a := range addresses {
+			addr, _ := a.(map[string]interface{})
+			address, _ := addr["address"].(string)
+			gateway, _ := addr["gateway"].(string)
+			_, subnet, err := net.ParseCIDR(address)
+			gw := net.ParseIP(gateway)
+			if err != nil || gw == nil || subnet.Contains(gw) {
+				continue
+			}
+			findings = append(findings, lintFinding{
+				Path:    plugin.Path.Child("ipam", "addresses").Index(i).Child("gateway"),
+				Message: fmt.Sprintf("gateway %s is outside %s, it is only reachable through an on-link route", gateway, subnet.String()),
+			})
+		}
+	}
+	return findings
+}
+
+// lintRuleNames returns the names of the lint rules, sorted
+func lintRuleNames() []string {
+	names := make([]string, 0, len(lintRules))
+	for _, rule := range lintRules {
+		names = append(names, rule.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+var _ = Describe("Lint rules", func() {
+
+	AfterEach(func() {
+		for _, name := range lintRuleNames() {
+			Expect(SetLintSeverities(name + "=" + LintSeverityWarning)).To(Succeed())
+		}
+		SetOverlayPluginTypes(DefaultOverlayPluginTypes)
+	})
+
+	DescribeTable("warnings",
+		func(config string, expected []string) {
+			allowed, warnings, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net", config), authenticationv1.UserInfo{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+			Expect(warnings).To(Equal(expected))
+		},
+		Entry("clean config",
+			`{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "ipam": {"type": "host-local", "subnet": "10.1.1.0/24", "rangeEnd": "10.1.1.99"}}`,
+			nil,
+		),
+		Entry("missing cniVersion",
+			`{"type": "macvlan"}`,
+			[]string{"spec.config.cniVersion: cniVersion is not set, plugins fall back to the oldest version they support (missing-cni-version)"},
+		),
+		Entry("deprecated cniVersion",
+			`{"cniVersion": "0.2.0", "type": "macvlan"}`,
+			[]string{"spec.config.cniVersion: cniVersion 0.2.0 is deprecated, use 0.3.1 or later (deprecated-cni-version)"},
+		),
+		Entry("host-local without rangeEnd",
+			`{"cniVersion": "0.3.1", "name": "n", "plugins": [{"type": "bridge", "ipam": {"type": "host-local", "ranges": [[{"subnet": "10.1.1.0/24", "rangeStart": "10.1.1.10"}]]}}]}`,
+			[]string{"spec.config.plugins[0].ipam.ranges[0][0].rangeEnd: rangeEnd is not set, addresses are allocated up to the end of the subnet (host-local-open-range)"},
+		),
+		Entry("vxlan overlay without mtu",
+			`{"cniVersion": "0.3.1", "type": "vxlan"}`,
+			[]string{"spec.config.mtu: mtu is not set on vxlan overlay, encapsulated packets may exceed the MTU of the underlying network (overlay-missing-mtu)"},
+		),
+		Entry("overlay without mtu",
+			`{"cniVersion": "0.3.1", "type": "ovn-k8s-cni-overlay", "topology": "layer2"}`,
+			[]string{"spec.config.mtu: mtu is not set on ovn-k8s-cni-overlay overlay, encapsulated packets may exceed the MTU of the underlying network (overlay-missing-mtu)"},
+		),
+		Entry("static gateway outside the subnet",
+			`{"cniVersion": "0.3.1", "type": "macvlan", "ipam": {"type": "static", "addresses": [{"address": "3ffe:ffff:0:01ff::1/64", "gateway": "3ffe:ffff:0::1"}]}}`,
+			[]string{"spec.config.ipam.addresses[0].gateway: gateway 3ffe:ffff:0::1 is outside 3ffe:ffff:0:1ff::/64, it is only reachable through an on-link route (static-gateway-outside-subnet)"},
+		),
+	)
+
+	It("should check the configured overlay plugin types", func() {
+		SetOverlayPluginTypes("geneve, vxlan")
+		_, warnings, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net", `{"cniVersion": "0.3.1", "type": "geneve"}`), authenticationv1.UserInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(Equal([]string{"spec.config.mtu: mtu is not set on geneve overlay, encapsulated packets may exceed the MTU of the underlying network (overlay-missing-mtu)"}))
+
+		_, warnings, err = validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net", `{"cniVersion": "0.3.1", "type": "ovn-k8s-cni-overlay"}`), authenticationv1.UserInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should deny findings of error rules", func() {
+		Expect(SetLintSeverities("missing-cni-version=error")).To(Succeed())
+		allowed, _, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net", `{"type": "macvlan"}`), authenticationv1.UserInfo{})
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError("spec.config.cniVersion: Forbidden: cniVersion is not set, plugins fall back to the oldest version they support (missing-cni-version)"))
+	})
+
+	It("should skip rules turned off", func() {
+		Expect(SetLintSeverities("missing-cni-version=off")).To(Succeed())
+		_, warnings, err := validateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net", `{"type": "macvlan"}`), authenticationv1.UserInfo{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	DescribeTable("invalid severity overrides",
+		func(severities string) {
+			Expect(SetLintSeverities(severities)).NotTo(Succeed())
+		},
+		Entry("unknown rule", "no-such-rule=error"),
+		Entry("unknown severity", "missing-cni-version=fatal"),
+		Entry("missing severity", "missing-cni-version"),
+	)
+})
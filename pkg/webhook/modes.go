@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/localmetrics"
+	"github.com/pkg/errors"
+)
+
+// Modes of the rule sets, i.e. what the webhook does with a request violating
+// the rules
+const (
+	// ModeEnforce denies the request
+	ModeEnforce = "enforce"
+	// ModeWarn admits the request with an admission warning
+	ModeWarn = "warn"
+	// ModeAudit admits the request, logging and counting the violation
+	ModeAudit = "audit"
+)
+
+// Rule sets that can be switched to another mode
+const (
+	// RuleSetValidate is the net-attach-def validation of /validate
+	RuleSetValidate = "validate"
+	// RuleSetIsolate is the pod network annotation validation of /isolate
+	RuleSetIsolate = "isolate"
+
+	// modeLabelPrefix followed by <rule set>-mode is the namespace label
+	// overriding the cluster-wide mode of a rule set
+	modeLabelPrefix = "k8s.v1.cni.cncf.io/"
+)
+
+// ruleSetModes are the cluster-wide modes of the rule sets
+var ruleSetModes = map[string]string{
+	RuleSetValidate: ModeEnforce,
+	RuleSetIsolate:  ModeEnforce,
+}
+
+func parseMode(ruleSet, mode string) (string, error) {
+	switch mode {
+	case ModeEnforce, ModeWarn, ModeAudit:
+		return mode, nil
+	}
+	return "", errors.Errorf("invalid %s mode '%s', must be one of %s, %s or %s", ruleSet, mode, ModeEnforce, ModeWarn, ModeAudit)
+}
+
+// SetRuleSetMode sets the cluster-wide mode of a rule set
+func SetRuleSetMode(ruleSet, mode string) error {
+	if _, ok := ruleSetModes[ruleSet]; !ok {
+		return errors.Errorf("unknown rule set '%s'", ruleSet)
+	}
+	m, err := parseMode(ruleSet, mode)
+	if err != nil {
+		return err
+	}
+	ruleSetModes[ruleSet] = m
+	return nil
+}
+
+// modeLabel returns the namespace label overriding the mode of a rule set
+func modeLabel(ruleSet string) string {
+	return modeLabelPrefix + ruleSet + "-mode"
+}
+
+// namespaceMode returns the mode of a rule set in a namespace, i.e. the one
+// of the namespace label if set and valid, else the cluster-wide one
+func namespaceMode(ruleSet, namespace string) string {
+	mode := ruleSetModes[ruleSet]
+	ns := getNamespace(namespace)
+	if ns == nil {
+		return mode
+	}
+	label, ok := ns.Labels[modeLabel(ruleSet)]
+	if !ok {
+		return mode
+	}
+	m, err := parseMode(ruleSet, label)
+	if err != nil {
+		glog.Warningf("ignoring label of namespace %s: %v", namespace, err)
+		return mode
+	}
+	return m
+}
+
+// applyMode handles a request that the rule set denied according to the mode
+// of the namespace: in warn mode the request is admitted with the denial as a
+// warning, in audit mode it is admitted and the denial is only logged. Both
+// count the violation.
+func applyMode(ruleSet, namespace string, allowed bool, warnings []string, err error) (bool, []string, error) {
+	if allowed && err == nil {
+		return allowed, warnings, err
+	}
+	mode := namespaceMode(ruleSet, namespace)
+	if mode == ModeEnforce {
+		return allowed, warnings, err
+	}
+
+	msg := fmt.Sprintf("request violates the %s rules", ruleSet)
+	if err != nil {
+		msg = err.Error()
+	}
+	localmetrics.IncAdmissionViolation(ruleSet, mode, namespace)
+	if mode == ModeWarn {
+		glog.Infof("admitting request in namespace %s in %s mode: %s", namespace, mode, msg)
+		return true, append(warnings, msg), nil
+	}
+	glog.Warningf("audit: admitting request in namespace %s violating the %s rules: %s", namespace, ruleSet, msg)
+	return true, warnings, nil
+}
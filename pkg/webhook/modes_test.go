@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// useNamespaces replaces the namespace cache with one holding namespaces of
+// the given labels, keyed by name
+func useNamespaces(labels map[string]map[string]string) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for name, l := range labels {
+		Expect(indexer.Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: l}})).To(Succeed())
+	}
+	namespaceIndexer = indexer
+	namespaceSynced = nil
+}
+
+// sendIsolateRequest sends the AdmissionReview to IsolateHandler and returns
+// the response
+func sendIsolateRequest(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	ar.TypeMeta = metav1.TypeMeta{Kind: "AdmissionReview", APIVersion: "admission.k8s.io/v1"}
+	body, err := json.Marshal(ar)
+	Expect(err).NotTo(HaveOccurred())
+
+	req := httptest.NewRequest("POST", "https://fakewebhook/isolate", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	IsolateHandler(w, req)
+
+	resp := &admissionv1.AdmissionReview{}
+	Expect(json.Unmarshal(w.Body.Bytes(), resp)).To(Succeed())
+	return resp.Response
+}
+
+var _ = Describe("Enforcement modes", func() {
+
+	const invalidNetworks = "foo/bar"
+
+	BeforeEach(func() {
+		useNamespaces(map[string]map[string]string{
+			"enforced": nil,
+			"warned":   {"k8s.v1.cni.cncf.io/isolate-mode": ModeWarn},
+			"audited":  {"k8s.v1.cni.cncf.io/isolate-mode": ModeAudit},
+			"typo":     {"k8s.v1.cni.cncf.io/isolate-mode": "permissive"},
+		})
+	})
+
+	AfterEach(func() {
+		namespaceIndexer = nil
+		Expect(SetRuleSetMode(RuleSetIsolate, ModeEnforce)).To(Succeed())
+	})
+
+	DescribeTable("namespace label overrides",
+		func(namespace string, allowed bool, warned bool) {
+			resp := sendIsolateRequest(newPodAdmissionReview(namespace, map[string]string{networksAnnotationKey: invalidNetworks}))
+			Expect(resp.Allowed).To(Equal(allowed))
+			if warned {
+				Expect(resp.Warnings).To(ConsistOf(ContainSubstring("no NetworkAttachmentGrant grants namespace")))
+			} else {
+				Expect(resp.Warnings).To(BeEmpty())
+			}
+		},
+		Entry("enforce by default", "enforced", false, false),
+		Entry("warn", "warned", true, true),
+		Entry("audit", "audited", true, false),
+		Entry("invalid label falls back to the cluster mode", "typo", false, false),
+		Entry("unknown namespace", "unknown", false, false),
+	)
+
+	It("should apply the cluster-wide mode", func() {
+		Expect(SetRuleSetMode(RuleSetIsolate, ModeAudit)).To(Succeed())
+		resp := sendIsolateRequest(newPodAdmissionReview("enforced", map[string]string{networksAnnotationKey: invalidNetworks}))
+		Expect(resp.Allowed).To(BeTrue())
+
+		resp = sendIsolateRequest(newPodAdmissionReview("warned", map[string]string{networksAnnotationKey: invalidNetworks}))
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(HaveLen(1))
+	})
+
+	It("should not change the mode of the other rule set", func() {
+		Expect(SetRuleSetMode(RuleSetIsolate, ModeAudit)).To(Succeed())
+		Expect(namespaceMode(RuleSetValidate, "enforced")).To(Equal(ModeEnforce))
+	})
+
+	It("should reject invalid modes", func() {
+		Expect(SetRuleSetMode(RuleSetIsolate, "permissive")).NotTo(Succeed())
+		Expect(SetRuleSetMode("mutate", ModeWarn)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,147 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/containernetworking/cni/pkg/version"
+	"github.com/golang/glog"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// DefaultCNIVersion is the cniVersion filled in NADs that do not set one
+const DefaultCNIVersion = "0.3.1"
+
+var defaultCNIVersion = DefaultCNIVersion
+
+// SetDefaultCNIVersion sets the cniVersion the mutating webhook fills in
+// NADs that do not set one; an empty version disables the defaulting
+func SetDefaultCNIVersion(cniVersion string) error {
+	if cniVersion != "" {
+		if _, _, _, err := version.ParseVersion(cniVersion); err != nil {
+			return errors.Wrapf(err, "invalid default cniVersion '%s'", cniVersion)
+		}
+	}
+	defaultCNIVersion = cniVersion
+	return nil
+}
+
+// decodeCNIConfig decodes a CNI config into a generic JSON object, keeping
+// numbers as they were written
+func decodeCNIConfig(config []byte) (map[string]interface{}, error) {
+	var c map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(config))
+	decoder.UseNumber()
+	if err := decoder.Decode(&c); err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, errors.New("CNI config must be a JSON object")
+	}
+	return c, nil
+}
+
+// encodeCNIConfig encodes a CNI config into its canonical form, i.e. compact
+// JSON with object keys sorted
+func encodeCNIConfig(c map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(c); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+// mutateNetworkAttachmentDefinition returns the JSONPatch operations that
+// default and canonicalize the NAD config, if any
+func mutateNetworkAttachmentDefinition(netAttachDef netv1.NetworkAttachmentDefinition) ([]jsonPatchOperation, error) {
+	if netAttachDef.Spec.Config == "" {
+		return nil, nil
+	}
+
+	c, err := decodeCNIConfig([]byte(netAttachDef.Spec.Config))
+	if err != nil {
+		// leave it to the validating webhook to reject it
+		glog.Infof("not mutating net-attach-def %s/%s: %v", netAttachDef.Namespace, netAttachDef.Name, err)
+		return nil, nil
+	}
+	if n, ok := c["name"]; !ok || n == "" {
+		c["name"] = netAttachDef.GetName()
+	}
+	if v, ok := c["cniVersion"]; (!ok || v == "") && defaultCNIVersion != "" {
+		c["cniVersion"] = defaultCNIVersion
+	}
+
+	configBytes, err := encodeCNIConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if string(configBytes) == netAttachDef.Spec.Config {
+		return nil, nil
+	}
+
+	return []jsonPatchOperation{{
+		Operation: "replace",
+		Path:      "/spec/config",
+		Value:     string(configBytes),
+	}}, nil
+}
+
+// MutateHandler handles net-attach-def mutation requests
+func MutateHandler(w http.ResponseWriter, req *http.Request) {
+	ar, httpStatus, err := readAdmissionReview(req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus)
+		return
+	}
+
+	netAttachDef, err := deserializeNetworkAttachmentDefinition(ar)
+	if err != nil {
+		handleValidationError(w, ar, err)
+		return
+	}
+
+	patch, err := mutateNetworkAttachmentDefinition(netAttachDef)
+	if err != nil {
+		handleValidationError(w, ar, err)
+		return
+	}
+
+	err = prepareAdmissionReviewResponse(true, "", ar)
+	if err != nil {
+		glog.Error(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(patch) > 0 {
+		patchBytes, err := json.Marshal(patch)
+		if err != nil {
+			glog.Error(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		glog.Infof("mutating net-attach-def %s/%s: %s", netAttachDef.Namespace, netAttachDef.Name, patchBytes)
+		patchType := admissionv1.PatchTypeJSONPatch
+		ar.Response.Patch = patchBytes
+		ar.Response.PatchType = &patchType
+	}
+	writeResponse(w, ar)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("Mutating webhook", func() {
+
+	AfterEach(func() {
+		Expect(SetDefaultCNIVersion(DefaultCNIVersion)).To(Succeed())
+	})
+
+	DescribeTable("Network Attachment Definition mutation",
+		func(config string, expected string) {
+			patch, err := mutateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net", config))
+			Expect(err).NotTo(HaveOccurred())
+			if expected == "" {
+				Expect(patch).To(BeEmpty())
+				return
+			}
+			Expect(patch).To(HaveLen(1))
+			Expect(patch[0].Operation).To(Equal("replace"))
+			Expect(patch[0].Path).To(Equal("/spec/config"))
+			Expect(patch[0].Value).To(Equal(expected))
+		},
+		Entry("empty config", "", ""),
+		Entry("invalid JSON is left to validation", `{"type": `, ""),
+		Entry("already canonical", `{"cniVersion":"0.4.0","name":"n","type":"macvlan"}`, ""),
+		Entry("fills name and cniVersion",
+			`{"type": "macvlan"}`,
+			`{"cniVersion":"0.3.1","name":"my-net","type":"macvlan"}`,
+		),
+		Entry("sorts keys and keeps numbers and strings as written",
+			`{
+				"type": "bridge", "name": "br", "cniVersion": "1.0.0",
+				"mtu": 9000, "vlan": 100000000000000000001,
+				"ipam": {"type": "host-local", "subnet": "10.1.0.0/24", "dataDir": "/var/lib/<cni>"}
+			}`,
+			`{"cniVersion":"1.0.0","ipam":{"dataDir":"/var/lib/<cni>","subnet":"10.1.0.0/24","type":"host-local"},"mtu":9000,"name":"br","type":"bridge","vlan":100000000000000000001}`,
+		),
+	)
+
+	It("should not default cniVersion when disabled", func() {
+		Expect(SetDefaultCNIVersion("")).To(Succeed())
+		patch, err := mutateNetworkAttachmentDefinition(*newNetAttachDef("default", "my-net", `{"type": "macvlan"}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(patch[0].Value).To(Equal(`{"name":"my-net","type":"macvlan"}`))
+	})
+
+	It("should reject an invalid default cniVersion", func() {
+		Expect(SetDefaultCNIVersion("latest")).NotTo(Succeed())
+	})
+
+	It("should return a JSONPatch in the admission response", func() {
+		nad := newNetAttachDef("default", "my-net", `{"type": "macvlan"}`)
+		raw, err := json.Marshal(nad)
+		Expect(err).NotTo(HaveOccurred())
+		body, err := json.Marshal(&admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{Kind: "AdmissionReview", APIVersion: "admission.k8s.io/v1"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:    "fake-uid",
+				Object: runtime.RawExtension{Raw: raw},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		req := httptest.NewRequest("POST", "https://fakewebhook/mutate", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		MutateHandler(w, req)
+
+		ar := &admissionv1.AdmissionReview{}
+		Expect(json.Unmarshal(w.Body.Bytes(), ar)).To(Succeed())
+		Expect(ar.Response.Allowed).To(BeTrue())
+		Expect(*ar.Response.PatchType).To(Equal(admissionv1.PatchTypeJSONPatch))
+		Expect(string(ar.Response.Patch)).To(Equal(`[{"op":"replace","path":"/spec/config","value":"{\"cniVersion\":\"0.3.1\",\"name\":\"my-net\",\"type\":\"macvlan\"}"}]`))
+	})
+})
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+var missingNetworkPolicy = PolicyDeny
+
+// SetMissingNetworkPolicy sets how pods referencing NADs that do not exist
+// are handled
+func SetMissingNetworkPolicy(policy string) error {
+	p, err := parsePolicy("missing network", policy)
+	if err != nil {
+		return err
+	}
+	missingNetworkPolicy = p
+	return nil
+}
+
+// resolveNetworkNamespace returns the namespace a network selection element
+// refers to, given the namespace of the pod
+func resolveNetworkNamespace(network *types.NetworkSelectionElement, podNamespace string) string {
+	if network.Namespace == "" || network.Namespace == namespaceConstraint {
+		return podNamespace
+	}
+	return network.Namespace
+}
+
+// describeAttachment names the attachment at index i of the pod networks in
+// messages
+func describeAttachment(networks []*types.NetworkSelectionElement, i int, podNamespace string) string {
+	return fmt.Sprintf("attachment %d (%s/%s)", i+1, resolveNetworkNamespace(networks[i], podNamespace), networks[i].Name)
+}
+
+// checkNetworksExist verifies every NAD referenced by the pod exists.
+// Depending on the missing network policy the missing references are
+// returned as an error or as warnings.
+func checkNetworksExist(annotation string, networks []*types.NetworkSelectionElement, podNamespace string, users []authenticationv1.UserInfo) ([]string, error) {
+	if missingNetworkPolicy == PolicyIgnore || len(networks) == 0 {
+		return nil, nil
+	}
+	if !netAttachDefCacheReady() {
+		glog.Warning("net-attach-def cache is not ready, skipping network existence check")
+		return nil, nil
+	}
+
+	var missing []string
+	for _, network := range networks {
+		namespace := resolveNetworkNamespace(network, podNamespace)
+		netAttachDef, err := getNetAttachDef(namespace, network.Name)
+		if err != nil {
+			return nil, err
+		}
+		if netAttachDef == nil {
+			missing = append(missing, describeMissingNetwork(namespace, network.Name, podNamespace, users))
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	msg := fmt.Sprintf("%s annotation refers to net-attach-defs that do not exist: %s", annotation, strings.Join(missing, ", "))
+	glog.Info(msg)
+	return applyPolicy(missingNetworkPolicy, msg)
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newPodAdmissionReview returns a CREATE AdmissionReview of a pod with the
+// given annotations
+func newPodAdmissionReview(namespace string, annotations map[string]string) *admissionv1.AdmissionReview {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+	}
+	raw, err := json.Marshal(pod)
+	Expect(err).NotTo(HaveOccurred())
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "fake-uid",
+			Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Pod"},
+			Namespace: namespace,
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+var _ = Describe("Network existence check", func() {
+
+	BeforeEach(func() {
+		useNetAttachDefs(
+			newNetAttachDef("default", "macvlan-conf", `{"cniVersion": "0.3.1", "type": "macvlan"}`),
+			newNetAttachDef("default", "sriov-conf", `{"cniVersion": "0.3.1", "type": "sriov"}`),
+		)
+	})
+
+	AfterEach(func() {
+		netAttachDefIndexer = nil
+		Expect(SetMissingNetworkPolicy(PolicyDeny)).To(Succeed())
+	})
+
+	DescribeTable("pod network references",
+		func(networks string, allowed bool, message string) {
+			actual, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: networks}))
+			Expect(actual).To(Equal(allowed))
+			if message != "" {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("existing networks", "macvlan-conf,sriov-conf@net2", true, ""),
+		Entry("existing network in JSON form", `[{"name": "macvlan-conf"}]`, true, ""),
+		Entry("misspelled network", "macvlan-conf,srivo-conf", false, "do not exist: default/srivo-conf"),
+		Entry("several missing networks", `[{"name": "foo"}, {"name": "bar"}]`, false, "default/foo, default/bar"),
+	)
+
+	It("should only warn when configured to", func() {
+		Expect(SetMissingNetworkPolicy(PolicyWarn)).To(Succeed())
+		allowed, warnings, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: "foo"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(warnings).To(ConsistOf(ContainSubstring("default/foo")))
+	})
+
+	It("should skip the check while the cache is not ready", func() {
+		netAttachDefIndexer = nil
+		allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: "foo"}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+})
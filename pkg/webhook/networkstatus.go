@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+// deprecatedNetworkStatusAnnotationKey is the network status annotation of
+// older Multus versions
+const deprecatedNetworkStatusAnnotationKey = "k8s.v1.cni.cncf.io/networks-status"
+
+// serviceAccountUsernamePrefix followed by namespace:name is the username
+// of a service account
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+// DefaultNetworkStatusWriters are the service accounts, as namespace/name,
+// allowed to write the network status annotations of pods
+const DefaultNetworkStatusWriters = "kube-system/multus"
+
+// networkStatusAnnotationKeys are the annotations Multus reports the
+// attachments of a pod in, which other tools trust
+var networkStatusAnnotationKeys = []string{netv1.NetworkStatusAnnot, deprecatedNetworkStatusAnnotationKey}
+
+// networkStatusWriters are the usernames of the service accounts allowed to
+// write the network status annotations
+var networkStatusWriters = mustParseNetworkStatusWriters(DefaultNetworkStatusWriters)
+
+// SetNetworkStatusWriters sets the service accounts allowed to write the
+// network status annotations of pods, given as a comma separated list of
+// namespace/name
+func SetNetworkStatusWriters(writers string) error {
+	parsed, err := parseNetworkStatusWriters(writers)
+	if err != nil {
+		return err
+	}
+	networkStatusWriters = parsed
+	return nil
+}
+
+func parseNetworkStatusWriters(writers string) (map[string]bool, error) {
+	return parseServiceAccounts("network status writer", writers)
+}
+
+// parseServiceAccounts returns the usernames of a comma separated list of
+// service accounts given as namespace/name
+func parseServiceAccounts(what, accounts string) (map[string]bool, error) {
+	parsed := map[string]bool{}
+	for _, item := range strings.Split(accounts, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("invalid %s '%s', must be a service account in the form namespace/name", what, item)
+		}
+		parsed[serviceAccountUsernamePrefix+parts[0]+":"+parts[1]] = true
+	}
+	return parsed, nil
+}
+
+func mustParseNetworkStatusWriters(writers string) map[string]bool {
+	parsed, err := parseNetworkStatusWriters(writers)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// checkNetworkStatusAnnotations denies setting the network status
+// annotations of a pod, or of a pod template, at creation and changing them
+// on update, of the pod or of its status subresource, unless the requesting
+// user is one of the network status writers
+func checkNetworkStatusAnnotations(req *admissionv1.AdmissionRequest, obj podObject) error {
+	if networkStatusWriters[req.UserInfo.Username] {
+		return nil
+	}
+
+	var old podObject
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		var err error
+		if old, err = decodePodObject(req.Kind, req.OldObject.Raw); err != nil {
+			return err
+		}
+	}
+
+	var spoofed []string
+	for _, key := range networkStatusAnnotationKeys {
+		value, ok := obj.Meta.Annotations[key]
+		switch {
+		case old.Meta == nil && ok:
+			spoofed = append(spoofed, fmt.Sprintf("%s may not be set", key))
+		case old.Meta != nil && value != old.Meta.Annotations[key]:
+			spoofed = append(spoofed, fmt.Sprintf("%s may not be changed", key))
+		}
+	}
+	if len(spoofed) == 0 {
+		return nil
+	}
+	return errors.Errorf("network status annotations are written by Multus, and user %s is not allowed to write them: %s", req.UserInfo.Username, strings.Join(spoofed, "; "))
+}
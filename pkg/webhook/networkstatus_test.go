@@ -0,0 +1,150 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	multusUsername = "system:serviceaccount:kube-system:multus"
+	fakeStatus     = `[{"name": "default/macvlan-conf", "interface": "net1", "ips": ["10.1.1.20"]}]`
+)
+
+// newPodUpdateAdmissionReview returns the review of a request updating the
+// annotations of a pod
+func newPodUpdateAdmissionReview(username string, oldAnnotations, annotations map[string]string) *admissionv1.AdmissionReview {
+	ar := newPodAdmissionReview("default", annotations)
+	ar.Request.Operation = admissionv1.Update
+	ar.Request.UserInfo = authenticationv1.UserInfo{Username: username}
+	raw, err := json.Marshal(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", Annotations: oldAnnotations}})
+	Expect(err).NotTo(HaveOccurred())
+	ar.Request.OldObject = runtime.RawExtension{Raw: raw}
+	return ar
+}
+
+var _ = Describe("Network status annotations", func() {
+
+	AfterEach(func() {
+		networkStatusWriters = mustParseNetworkStatusWriters(DefaultNetworkStatusWriters)
+	})
+
+	DescribeTable("pod creation",
+		func(username string, annotations map[string]string, message string) {
+			ar := newPodAdmissionReview("default", annotations)
+			ar.Request.UserInfo = authenticationv1.UserInfo{Username: username}
+			allowed, _, err := analyzeIsolationAnnotation(ar)
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("without status", "alice", map[string]string{}, ""),
+		Entry("with network-status", "alice", map[string]string{netv1.NetworkStatusAnnot: fakeStatus},
+			"user alice is not allowed to write them: k8s.v1.cni.cncf.io/network-status may not be set"),
+		Entry("with deprecated networks-status", "alice", map[string]string{deprecatedNetworkStatusAnnotationKey: fakeStatus},
+			"k8s.v1.cni.cncf.io/networks-status may not be set"),
+		Entry("with an empty network-status", "alice", map[string]string{netv1.NetworkStatusAnnot: ""},
+			"k8s.v1.cni.cncf.io/network-status may not be set"),
+		Entry("by a network status writer", multusUsername, map[string]string{netv1.NetworkStatusAnnot: fakeStatus}, ""),
+	)
+
+	DescribeTable("pod update",
+		func(username string, oldAnnotations, annotations map[string]string, message string) {
+			allowed, _, err := analyzeIsolationAnnotation(newPodUpdateAdmissionReview(username, oldAnnotations, annotations))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("set by Multus", multusUsername, map[string]string{}, map[string]string{netv1.NetworkStatusAnnot: fakeStatus}, ""),
+		Entry("unchanged by a user", "alice",
+			map[string]string{netv1.NetworkStatusAnnot: fakeStatus}, map[string]string{netv1.NetworkStatusAnnot: fakeStatus, "team": "a"}, ""),
+		Entry("set by a user", "alice", map[string]string{}, map[string]string{netv1.NetworkStatusAnnot: fakeStatus},
+			"k8s.v1.cni.cncf.io/network-status may not be changed"),
+		Entry("changed by a user", "alice",
+			map[string]string{netv1.NetworkStatusAnnot: fakeStatus}, map[string]string{netv1.NetworkStatusAnnot: `[]`},
+			"k8s.v1.cni.cncf.io/network-status may not be changed"),
+		Entry("removed by a user", "alice",
+			map[string]string{deprecatedNetworkStatusAnnotationKey: fakeStatus}, map[string]string{},
+			"k8s.v1.cni.cncf.io/networks-status may not be changed"),
+	)
+
+	It("should deny spoofed status in pod templates", func() {
+		deployment := newDeployment("macvlan-conf")
+		deployment.Spec.Template.Annotations[netv1.NetworkStatusAnnot] = fakeStatus
+		allowed, _, err := analyzeIsolationAnnotation(newWorkloadAdmissionReview(deploymentKind, deployment))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("k8s.v1.cni.cncf.io/network-status may not be set")))
+	})
+
+	It("should check updates of the status subresource", func() {
+		ar := newPodUpdateAdmissionReview("alice", map[string]string{}, map[string]string{netv1.NetworkStatusAnnot: fakeStatus})
+		ar.Request.Resource = metav1.GroupVersionResource{Version: "v1", Resource: "pods"}
+		ar.Request.SubResource = "status"
+		allowed, _, err := analyzeIsolationAnnotation(ar)
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("k8s.v1.cni.cncf.io/network-status may not be changed")))
+
+		ar = newPodUpdateAdmissionReview("system:node:worker-1",
+			map[string]string{netv1.NetworkStatusAnnot: fakeStatus}, map[string]string{netv1.NetworkStatusAnnot: fakeStatus})
+		ar.Request.Resource = metav1.GroupVersionResource{Version: "v1", Resource: "pods"}
+		ar.Request.SubResource = "status"
+		allowed, _, err = analyzeIsolationAnnotation(ar)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should not check unchanged networks annotations on update", func() {
+		annotations := map[string]string{networksAnnotationKey: "infra/storage-net"}
+		allowed, _, err := analyzeIsolationAnnotation(newPodUpdateAdmissionReview(multusUsername, annotations,
+			map[string]string{networksAnnotationKey: "infra/storage-net", netv1.NetworkStatusAnnot: fakeStatus}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should allow the configured service accounts", func() {
+		Expect(SetNetworkStatusWriters("multus/multus-sa, kube-system/whereabouts")).To(Succeed())
+		Expect(networkStatusWriters).To(Equal(map[string]bool{
+			"system:serviceaccount:multus:multus-sa":        true,
+			"system:serviceaccount:kube-system:whereabouts": true,
+		}))
+
+		allowed, _, err := analyzeIsolationAnnotation(newPodUpdateAdmissionReview(multusUsername, map[string]string{}, map[string]string{netv1.NetworkStatusAnnot: fakeStatus}))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject invalid service accounts", func() {
+		Expect(SetNetworkStatusWriters("multus")).To(MatchError(ContainSubstring("invalid network status writer 'multus'")))
+	})
+})
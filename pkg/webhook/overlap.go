@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+)
+
+const defaultBridgeName = "cni0"
+
+var subnetOverlapPolicy = PolicyDeny
+
+// SetSubnetOverlapPolicy sets how NADs whose IPAM ranges overlap another
+// NAD's ranges on the same L2 domain are handled
+func SetSubnetOverlapPolicy(policy string) error {
+	p, err := parsePolicy("subnet overlap", policy)
+	if err != nil {
+		return err
+	}
+	subnetOverlapPolicy = p
+	return nil
+}
+
+// getL2Domain returns a key identifying the L2 domain the first
+// interface-creating plugin of the config attaches to, i.e. its master
+// interface, bridge or host device along with its VLAN, or an empty string
+// if it cannot be determined
+func getL2Domain(confBytes []byte) string {
+	plugins, err := getPluginConfs(confBytes)
+	if err != nil {
+		return ""
+	}
+	for _, plugin := range plugins {
+		str := func(key string) string {
+			s, _ := plugin.Raw[key].(string)
+			return s
+		}
+		num := func(key string) int {
+			n, _ := plugin.Raw[key].(float64)
+			return int(n)
+		}
+		switch plugin.Type {
+		case "macvlan", "ipvlan":
+			if master := str("master"); master != "" {
+				return "master/" + master
+			}
+			return ""
+		case "vlan":
+			if master := str("master"); master != "" {
+				return fmt.Sprintf("master/%s/vlan/%d", master, num("vlanId"))
+			}
+			return ""
+		case "bridge":
+			bridge := str("bridge")
+			if bridge == "" {
+				bridge = defaultBridgeName
+			}
+			if vlan := num("vlan"); vlan != 0 {
+				return fmt.Sprintf("bridge/%s/vlan/%d", bridge, vlan)
+			}
+			return "bridge/" + bridge
+		case "host-device":
+			if device := str("device"); device != "" {
+				return "device/" + device
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// getHostLocalRanges returns every host-local range of the config
+func getHostLocalRanges(confBytes []byte) []*ipamRange {
+	plugins, err := getPluginConfs(confBytes)
+	if err != nil {
+		return nil
+	}
+
+	var ranges []*ipamRange
+	for _, plugin := range plugins {
+		ipam, ok := plugin.Raw["ipam"].(map[string]interface{})
+		if !ok || ipam["type"] != "host-local" {
+			continue
+		}
+		raw, err := json.Marshal(ipam)
+		if err != nil {
+			continue
+		}
+		conf := &hostLocalIPAMConf{}
+		if err := json.Unmarshal(raw, conf); err != nil {
+			continue
+		}
+		rangeConfs := []hostLocalRangeConf{}
+		if conf.Subnet != "" {
+			rangeConfs = append(rangeConfs, conf.hostLocalRangeConf)
+		}
+		for _, rangeSet := range conf.Ranges {
+			rangeConfs = append(rangeConfs, rangeSet...)
+		}
+		for _, rc := range rangeConfs {
+			if r, errs := parseHostLocalRange(rc, plugin.Path); r != nil && len(errs) == 0 {
+				ranges = append(ranges, r)
+			}
+		}
+	}
+	return ranges
+}
+
+// checkSubnetOverlap looks for other NADs on the same L2 domain whose
+// host-local ranges overlap the ones of netAttachDef. Depending on the subnet
+// overlap policy the conflicts are returned as an error or as warnings.
+func checkSubnetOverlap(netAttachDef netv1.NetworkAttachmentDefinition) ([]string, error) {
+	if subnetOverlapPolicy == PolicyIgnore || netAttachDef.Spec.Config == "" {
+		return nil, nil
+	}
+	if !netAttachDefCacheReady() {
+		glog.Warning("net-attach-def cache is not ready, skipping subnet overlap check")
+		return nil, nil
+	}
+
+	confBytes := []byte(netAttachDef.Spec.Config)
+	domain := getL2Domain(confBytes)
+	ranges := getHostLocalRanges(confBytes)
+	if domain == "" || len(ranges) == 0 {
+		return nil, nil
+	}
+
+	objs, err := netAttachDefIndexer.ByIndex(netAttachDefL2DomainIndex, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []string
+	for _, obj := range objs {
+		other, ok := obj.(*netv1.NetworkAttachmentDefinition)
+		if !ok || (other.Namespace == netAttachDef.Namespace && other.Name == netAttachDef.Name) {
+			continue
+		}
+		for _, otherRange := range getHostLocalRanges([]byte(other.Spec.Config)) {
+			if r := findOverlappingRange(ranges, otherRange); r != nil {
+				conflicts = append(conflicts, fmt.Sprintf("%s/%s (%s overlaps %s)", other.Namespace, other.Name, r.Subnet.String(), otherRange.Subnet.String()))
+				break
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil, nil
+	}
+	sort.Strings(conflicts)
+
+	msg := fmt.Sprintf("IPAM ranges overlap with other net-attach-defs on %s: %s", domain, strings.Join(conflicts, ", "))
+	glog.Info(msg)
+	return applyPolicy(subnetOverlapPolicy, msg)
+}
+
+func findOverlappingRange(ranges []*ipamRange, other *ipamRange) *ipamRange {
+	for _, r := range ranges {
+		if rangesOverlap(r, other) {
+			return r
+		}
+	}
+	return nil
+}
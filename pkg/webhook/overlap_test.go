@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newNetAttachDef(namespace, name, config string) *netv1.NetworkAttachmentDefinition {
+	return &netv1.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       netv1.NetworkAttachmentDefinitionSpec{Config: config},
+	}
+}
+
+// useNetAttachDefs replaces the NAD cache with one holding the given NADs
+func useNetAttachDefs(netAttachDefs ...*netv1.NetworkAttachmentDefinition) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, netAttachDefIndexers())
+	for _, netAttachDef := range netAttachDefs {
+		Expect(indexer.Add(netAttachDef)).To(Succeed())
+	}
+	netAttachDefIndexer = indexer
+	netAttachDefSynced = nil
+}
+
+var _ = Describe("Subnet overlap detection", func() {
+
+	BeforeEach(func() {
+		useNetAttachDefs(
+			newNetAttachDef("team-a", "macvlan-a", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "ipam": {"type": "host-local", "subnet": "10.10.0.0/24"}}`),
+			newNetAttachDef("team-a", "vlan-a", `{"cniVersion": "0.3.1", "type": "vlan", "master": "eth1", "vlanId": 100, "ipam": {"type": "host-local", "ranges": [[{"subnet": "10.20.0.0/24", "rangeStart": "10.20.0.10", "rangeEnd": "10.20.0.99"}]]}}`),
+		)
+	})
+
+	AfterEach(func() {
+		netAttachDefIndexer = nil
+		Expect(SetSubnetOverlapPolicy(PolicyDeny)).To(Succeed())
+	})
+
+	DescribeTable("checking a NAD against the cluster",
+		func(namespace, name, config string, shouldFail bool) {
+			_, err := checkSubnetOverlap(*newNetAttachDef(namespace, name, config))
+			if shouldFail {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+		},
+		Entry("overlapping subnet on the same master",
+			"team-b", "macvlan-b", `{"cniVersion": "0.3.1", "type": "ipvlan", "master": "eth1", "ipam": {"type": "host-local", "subnet": "10.10.0.128/25"}}`,
+			true,
+		),
+		Entry("same subnet on another master",
+			"team-b", "macvlan-b", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth2", "ipam": {"type": "host-local", "subnet": "10.10.0.0/24"}}`,
+			false,
+		),
+		Entry("same subnet on another vlan",
+			"team-b", "vlan-b", `{"cniVersion": "0.3.1", "type": "vlan", "master": "eth1", "vlanId": 200, "ipam": {"type": "host-local", "subnet": "10.20.0.0/24"}}`,
+			false,
+		),
+		Entry("overlapping allocation pool on the same vlan",
+			"team-b", "vlan-b", `{"cniVersion": "0.3.1", "type": "vlan", "master": "eth1", "vlanId": 100, "ipam": {"type": "host-local", "subnet": "10.20.0.0/24", "rangeStart": "10.20.0.50", "rangeEnd": "10.20.0.150"}}`,
+			true,
+		),
+		Entry("disjoint allocation pools of the same subnet",
+			"team-b", "vlan-b", `{"cniVersion": "0.3.1", "type": "vlan", "master": "eth1", "vlanId": 100, "ipam": {"type": "host-local", "subnet": "10.20.0.0/24", "rangeStart": "10.20.0.100", "rangeEnd": "10.20.0.199"}}`,
+			false,
+		),
+		Entry("update of the NAD itself",
+			"team-a", "macvlan-a", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "ipam": {"type": "host-local", "subnet": "10.10.0.0/23"}}`,
+			false,
+		),
+	)
+
+	It("should name the conflicting NAD", func() {
+		_, err := checkSubnetOverlap(*newNetAttachDef("team-b", "macvlan-b", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "ipam": {"type": "host-local", "subnet": "10.10.0.0/16"}}`))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("team-a/macvlan-a"))
+	})
+
+	It("should only warn when configured to", func() {
+		Expect(SetSubnetOverlapPolicy(PolicyWarn)).To(Succeed())
+		warnings, err := checkSubnetOverlap(*newNetAttachDef("team-b", "macvlan-b", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "ipam": {"type": "host-local", "subnet": "10.10.0.0/16"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0]).To(ContainSubstring("team-a/macvlan-a"))
+	})
+
+	It("should reject an unknown policy", func() {
+		Expect(SetSubnetOverlapPolicy("reject")).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,408 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	minMTU    = 68
+	maxMTU    = 65535
+	maxVlanID = 4094
+)
+
+var (
+	macvlanModes = []string{"bridge", "private", "vepa", "passthru"}
+	ipvlanModes  = []string{"l2", "l3", "l3s"}
+
+	pciAddressRegex = regexp.MustCompile(`^([0-9a-fA-F]{4}:)?[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
+)
+
+// pluginConf is a single plugin configuration of a NAD, either the whole
+// standalone config or one entry of a conflist 'plugins' array.
+type pluginConf struct {
+	Type string
+	Raw  map[string]interface{}
+	Path *field.Path
+}
+
+// commonPluginConf holds the keys every CNI plugin configuration may carry
+type commonPluginConf struct {
+	CNIVersion    string                 `json:"cniVersion,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	Type          string                 `json:"type"`
+	Capabilities  map[string]bool        `json:"capabilities,omitempty"`
+	IPAM          map[string]interface{} `json:"ipam,omitempty"`
+	DNS           map[string]interface{} `json:"dns,omitempty"`
+	RuntimeConfig map[string]interface{} `json:"runtimeConfig,omitempty"`
+	Args          map[string]interface{} `json:"args,omitempty"`
+	PrevResult    map[string]interface{} `json:"prevResult,omitempty"`
+}
+
+type macvlanConf struct {
+	commonPluginConf
+	Master          string  `json:"master"`
+	Mode            string  `json:"mode"`
+	MTU             int     `json:"mtu"`
+	Mac             string  `json:"mac,omitempty"`
+	LinkInContainer bool    `json:"linkInContainer"`
+	BcQueueLen      *uint32 `json:"bcqueuelen,omitempty"`
+}
+
+type ipvlanConf struct {
+	commonPluginConf
+	Master          string `json:"master"`
+	Mode            string `json:"mode"`
+	MTU             int    `json:"mtu"`
+	LinkInContainer bool   `json:"linkInContainer"`
+}
+
+type vlanTrunkConf struct {
+	MinID *int `json:"minID,omitempty"`
+	MaxID *int `json:"maxID,omitempty"`
+	ID    *int `json:"id,omitempty"`
+}
+
+type bridgeConf struct {
+	commonPluginConf
+	BrName                    string           `json:"bridge"`
+	IsGW                      bool             `json:"isGateway"`
+	IsDefaultGW               bool             `json:"isDefaultGateway"`
+	ForceAddress              bool             `json:"forceAddress"`
+	IPMasq                    bool             `json:"ipMasq"`
+	IPMasqBackend             string           `json:"ipMasqBackend,omitempty"`
+	MTU                       int              `json:"mtu"`
+	HairpinMode               bool             `json:"hairpinMode"`
+	PromiscMode               bool             `json:"promiscMode"`
+	Vlan                      int              `json:"vlan"`
+	VlanTrunk                 []*vlanTrunkConf `json:"vlanTrunk,omitempty"`
+	PreserveDefaultVlan       *bool            `json:"preserveDefaultVlan,omitempty"`
+	MacSpoofChk               bool             `json:"macspoofchk,omitempty"`
+	EnableDad                 bool             `json:"enabledad,omitempty"`
+	DisableContainerInterface bool             `json:"disableContainerInterface,omitempty"`
+	PortIsolation             bool             `json:"portIsolation,omitempty"`
+	Mac                       string           `json:"mac,omitempty"`
+}
+
+type hostDeviceConf struct {
+	commonPluginConf
+	Device     string `json:"device"`
+	HWAddr     string `json:"hwaddr"`
+	KernelPath string `json:"kernelpath"`
+	PCIAddr    string `json:"pciBusID"`
+}
+
+type vlanConf struct {
+	commonPluginConf
+	Master          string `json:"master"`
+	VlanID          *int   `json:"vlanId"`
+	MTU             int    `json:"mtu,omitempty"`
+	LinkInContainer bool   `json:"linkInContainer,omitempty"`
+}
+
+type ptpConf struct {
+	commonPluginConf
+	IPMasq        bool   `json:"ipMasq"`
+	IPMasqBackend string `json:"ipMasqBackend,omitempty"`
+	MTU           int    `json:"mtu"`
+}
+
+type loopbackConf struct {
+	commonPluginConf
+}
+
+// pluginValidator validates the configuration of a single CNI plugin type
+type pluginValidator func(plugin pluginConf) field.ErrorList
+
+// pluginValidators maps a CNI plugin 'type' to its typed validator; plugin
+// types that are not listed here are not checked beyond what libcni does
+var pluginValidators = map[string]pluginValidator{
+	"macvlan":     validateMacvlanConf,
+	"ipvlan":      validateIpvlanConf,
+	"bridge":      validateBridgeConf,
+	"host-device": validateHostDeviceConf,
+	"vlan":        validateVlanConf,
+	"ptp":         validatePtpConf,
+	"loopback":    validateLoopbackConf,
+	"tuning":      validateTuningConf,
+}
+
+// getPluginConfs splits a CNI config or conflist into its plugin configurations
+func getPluginConfs(confBytes []byte) ([]pluginConf, error) {
+	var c map[string]interface{}
+	if err := json.Unmarshal(confBytes, &c); err != nil {
+		return nil, err
+	}
+
+	root := field.NewPath("spec", "config")
+	p, ok := c["plugins"]
+	if !ok {
+		t, _ := c["type"].(string)
+		return []pluginConf{{Type: t, Raw: c, Path: root}}, nil
+	}
+
+	plugins, ok := p.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'plugins' must be a list")
+	}
+	confs := make([]pluginConf, 0, len(plugins))
+	for i, v := range plugins {
+		plugin, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("plugin at index %d must be an object", i)
+		}
+		t, _ := plugin["type"].(string)
+		confs = append(confs, pluginConf{Type: t, Raw: plugin, Path: root.Child("plugins").Index(i)})
+	}
+	return confs, nil
+}
+
+// validatePluginConfs checks the order of the plugin chain and runs the typed
+// validator of every known plugin type and IPAM type found in the config, and
+// returns all the problems found
+func validatePluginConfs(confBytes []byte) field.ErrorList {
+	plugins, err := getPluginConfs(confBytes)
+	if err != nil {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "config"), string(confBytes), err.Error())}
+	}
+
+	allErrs := validatePluginChain(plugins)
+	for _, plugin := range plugins {
+		if validator, ok := pluginValidators[plugin.Type]; ok {
+			allErrs = append(allErrs, validator(plugin)...)
+		}
+		if ipam, ok := plugin.Raw["ipam"]; ok {
+			allErrs = append(allErrs, validateIPAMConf(ipam, plugin.Path.Child("ipam"))...)
+		}
+	}
+	return allErrs
+}
+
+// decodePluginConf decodes the plugin configuration into the typed struct
+// pointed by out and reports type mismatches and unknown fields
+func decodePluginConf(plugin pluginConf, out interface{}) field.ErrorList {
+	return decodeConf(plugin.Raw, plugin.Path, fmt.Sprintf("plugin type '%s'", plugin.Type), out)
+}
+
+// decodeConf decodes a raw JSON object found at path into the typed struct
+// pointed by out and reports type mismatches and fields unknown to kind
+func decodeConf(raw map[string]interface{}, path *field.Path, kind string, out interface{}) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	known := jsonFieldNames(reflect.TypeOf(out).Elem())
+	var unknown []string
+	for k := range raw {
+		if _, ok := known[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		allErrs = append(allErrs, field.Forbidden(path.Child(k), fmt.Sprintf("unknown field for %s", kind)))
+	}
+
+	rawBytes, err := json.Marshal(raw)
+	if err != nil {
+		return append(allErrs, field.InternalError(path, err))
+	}
+	if err := json.Unmarshal(rawBytes, out); err != nil {
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok && typeErr.Field != "" {
+			allErrs = append(allErrs, field.Invalid(path.Child(typeErr.Field), typeErr.Value, fmt.Sprintf("must be of type %s", typeErr.Type)))
+		} else {
+			allErrs = append(allErrs, field.Invalid(path, string(rawBytes), err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// jsonFieldNames returns the JSON keys of a struct type, including the ones
+// of its embedded structs
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	names := map[string]struct{}{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			for k := range jsonFieldNames(f.Type) {
+				names[k] = struct{}{}
+			}
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		names[tag] = struct{}{}
+	}
+	return names
+}
+
+func validateMTU(mtu int, path *field.Path) field.ErrorList {
+	if mtu != 0 && (mtu < minMTU || mtu > maxMTU) {
+		return field.ErrorList{field.Invalid(path, mtu, fmt.Sprintf("must be between %d and %d", minMTU, maxMTU))}
+	}
+	return nil
+}
+
+func validateVlanID(id int, path *field.Path) field.ErrorList {
+	if id < 0 || id > maxVlanID {
+		return field.ErrorList{field.Invalid(path, id, fmt.Sprintf("must be between 0 and %d", maxVlanID))}
+	}
+	return nil
+}
+
+func validateEnum(value string, allowed []string, path *field.Path) field.ErrorList {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return field.ErrorList{field.NotSupported(path, value, allowed)}
+}
+
+func validateMacvlanConf(plugin pluginConf) field.ErrorList {
+	conf := &macvlanConf{}
+	allErrs := decodePluginConf(plugin, conf)
+	allErrs = append(allErrs, validateEnum(conf.Mode, macvlanModes, plugin.Path.Child("mode"))...)
+	allErrs = append(allErrs, validateMTU(conf.MTU, plugin.Path.Child("mtu"))...)
+	if conf.Mac != "" {
+		if _, err := net.ParseMAC(conf.Mac); err != nil {
+			allErrs = append(allErrs, field.Invalid(plugin.Path.Child("mac"), conf.Mac, "must be a valid MAC address"))
+		}
+	}
+	return allErrs
+}
+
+func validateIpvlanConf(plugin pluginConf) field.ErrorList {
+	conf := &ipvlanConf{}
+	allErrs := decodePluginConf(plugin, conf)
+	if _, ok := plugin.Raw["master"]; !ok {
+		allErrs = append(allErrs, field.Required(plugin.Path.Child("master"), "ipvlan requires a master interface"))
+	}
+	allErrs = append(allErrs, validateEnum(conf.Mode, ipvlanModes, plugin.Path.Child("mode"))...)
+	allErrs = append(allErrs, validateMTU(conf.MTU, plugin.Path.Child("mtu"))...)
+	return allErrs
+}
+
+func validateBridgeConf(plugin pluginConf) field.ErrorList {
+	conf := &bridgeConf{}
+	allErrs := decodePluginConf(plugin, conf)
+	allErrs = append(allErrs, validateMTU(conf.MTU, plugin.Path.Child("mtu"))...)
+	allErrs = append(allErrs, validateVlanID(conf.Vlan, plugin.Path.Child("vlan"))...)
+	if conf.Vlan != 0 && len(conf.VlanTrunk) > 0 {
+		allErrs = append(allErrs, field.Forbidden(plugin.Path.Child("vlanTrunk"), "cannot be set together with 'vlan'"))
+	}
+	for i, trunk := range conf.VlanTrunk {
+		trunkPath := plugin.Path.Child("vlanTrunk").Index(i)
+		if trunk == nil {
+			allErrs = append(allErrs, field.Required(trunkPath, "must be an object"))
+			continue
+		}
+		if trunk.ID != nil {
+			if trunk.MinID != nil || trunk.MaxID != nil {
+				allErrs = append(allErrs, field.Forbidden(trunkPath.Child("id"), "cannot be set together with 'minID'/'maxID'"))
+			}
+			if *trunk.ID < 1 || *trunk.ID > maxVlanID {
+				allErrs = append(allErrs, field.Invalid(trunkPath.Child("id"), *trunk.ID, fmt.Sprintf("must be between 1 and %d", maxVlanID)))
+			}
+			continue
+		}
+		if trunk.MinID == nil || trunk.MaxID == nil {
+			allErrs = append(allErrs, field.Required(trunkPath, "either 'id' or both 'minID' and 'maxID' must be set"))
+			continue
+		}
+		if *trunk.MinID < 1 || *trunk.MinID > maxVlanID {
+			allErrs = append(allErrs, field.Invalid(trunkPath.Child("minID"), *trunk.MinID, fmt.Sprintf("must be between 1 and %d", maxVlanID)))
+		}
+		if *trunk.MaxID < 1 || *trunk.MaxID > maxVlanID {
+			allErrs = append(allErrs, field.Invalid(trunkPath.Child("maxID"), *trunk.MaxID, fmt.Sprintf("must be between 1 and %d", maxVlanID)))
+		}
+		if *trunk.MinID > *trunk.MaxID {
+			allErrs = append(allErrs, field.Invalid(trunkPath.Child("minID"), *trunk.MinID, "must not be greater than 'maxID'"))
+		}
+	}
+	if conf.Mac != "" {
+		if _, err := net.ParseMAC(conf.Mac); err != nil {
+			allErrs = append(allErrs, field.Invalid(plugin.Path.Child("mac"), conf.Mac, "must be a valid MAC address"))
+		}
+	}
+	return allErrs
+}
+
+func validateHostDeviceConf(plugin pluginConf) field.ErrorList {
+	conf := &hostDeviceConf{}
+	allErrs := decodePluginConf(plugin, conf)
+
+	var set []string
+	for _, k := range []string{"device", "hwaddr", "kernelpath", "pciBusID"} {
+		if v, ok := plugin.Raw[k]; ok && v != "" {
+			set = append(set, k)
+		}
+	}
+	if len(set) > 1 {
+		allErrs = append(allErrs, field.Forbidden(plugin.Path, fmt.Sprintf("only one of 'device', 'hwaddr', 'kernelpath' or 'pciBusID' may be set, got %s", strings.Join(set, ", "))))
+	}
+	if conf.HWAddr != "" {
+		if _, err := net.ParseMAC(conf.HWAddr); err != nil {
+			allErrs = append(allErrs, field.Invalid(plugin.Path.Child("hwaddr"), conf.HWAddr, "must be a valid MAC address"))
+		}
+	}
+	if conf.PCIAddr != "" && !pciAddressRegex.MatchString(conf.PCIAddr) {
+		allErrs = append(allErrs, field.Invalid(plugin.Path.Child("pciBusID"), conf.PCIAddr, "must be a PCI address such as 0000:00:1f.6"))
+	}
+	return allErrs
+}
+
+func validateVlanConf(plugin pluginConf) field.ErrorList {
+	conf := &vlanConf{}
+	allErrs := decodePluginConf(plugin, conf)
+	if _, ok := plugin.Raw["master"]; !ok {
+		allErrs = append(allErrs, field.Required(plugin.Path.Child("master"), "vlan requires a master interface"))
+	}
+	if conf.VlanID == nil {
+		if _, ok := plugin.Raw["vlanId"]; !ok {
+			allErrs = append(allErrs, field.Required(plugin.Path.Child("vlanId"), "vlan requires a VLAN ID"))
+		}
+	} else {
+		allErrs = append(allErrs, validateVlanID(*conf.VlanID, plugin.Path.Child("vlanId"))...)
+	}
+	allErrs = append(allErrs, validateMTU(conf.MTU, plugin.Path.Child("mtu"))...)
+	return allErrs
+}
+
+func validatePtpConf(plugin pluginConf) field.ErrorList {
+	conf := &ptpConf{}
+	allErrs := decodePluginConf(plugin, conf)
+	if _, ok := plugin.Raw["ipam"]; !ok {
+		allErrs = append(allErrs, field.Required(plugin.Path.Child("ipam"), "ptp requires an IPAM configuration"))
+	}
+	allErrs = append(allErrs, validateMTU(conf.MTU, plugin.Path.Child("mtu"))...)
+	return allErrs
+}
+
+func validateLoopbackConf(plugin pluginConf) field.ErrorList {
+	return decodePluginConf(plugin, &loopbackConf{})
+}
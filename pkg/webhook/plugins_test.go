@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+)
+
+var _ = Describe("Plugin validation", func() {
+
+	DescribeTable("Per-plugin configuration validation",
+		func(config string, expectedErrs []string) {
+			errs := validatePluginConfs([]byte(config))
+			Expect(errs).To(HaveLen(len(expectedErrs)))
+			for i, e := range expectedErrs {
+				Expect(errs[i].Error()).To(ContainSubstring(e))
+			}
+		},
+		Entry("valid macvlan",
+			`{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth0", "mode": "bridge", "mtu": 1500}`,
+			[]string{},
+		),
+		Entry("misspelled macvlan mode",
+			`{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth0", "mode": "brige"}`,
+			[]string{`spec.config.mode: Unsupported value: "brige"`},
+		),
+		Entry("unknown field and out of range mtu",
+			`{"cniVersion": "0.3.1", "type": "macvlan", "mastre": "eth0", "mtu": 10}`,
+			[]string{"spec.config.mastre: Forbidden: unknown field", "spec.config.mtu: Invalid value: 10"},
+		),
+		Entry("ipvlan without master",
+			`{"cniVersion": "0.3.1", "type": "ipvlan", "mode": "l2"}`,
+			[]string{"spec.config.master: Required value"},
+		),
+		Entry("bridge with both vlan and vlanTrunk",
+			`{"cniVersion": "0.3.1", "plugins": [{"type": "bridge", "bridge": "br0", "vlan": 100, "vlanTrunk": [{"minID": 200, "maxID": 100}]}]}`,
+			[]string{"spec.config.plugins[0].vlanTrunk: Forbidden", "spec.config.plugins[0].vlanTrunk[0].minID: Invalid value: 200"},
+		),
+		Entry("bridge with wrong field type",
+			`{"cniVersion": "0.3.1", "type": "bridge", "isGateway": "yes"}`,
+			[]string{"spec.config.isGateway: Invalid value"},
+		),
+		Entry("host-device with several devices",
+			`{"cniVersion": "0.3.1", "type": "host-device", "device": "eth1", "pciBusID": "0000:00:1f.6"}`,
+			[]string{"only one of"},
+		),
+		Entry("vlan with out of range id",
+			`{"cniVersion": "0.3.1", "type": "vlan", "master": "eth0", "vlanId": 5000}`,
+			[]string{"spec.config.vlanId: Invalid value: 5000"},
+		),
+		Entry("vlan without id",
+			`{"cniVersion": "0.3.1", "type": "vlan", "master": "eth0"}`,
+			[]string{"spec.config.vlanId: Required value"},
+		),
+		Entry("ptp without ipam",
+			`{"cniVersion": "0.3.1", "type": "ptp"}`,
+			[]string{"spec.config.ipam: Required value"},
+		),
+		Entry("loopback with extra field",
+			`{"cniVersion": "0.3.1", "type": "loopback", "master": "eth0"}`,
+			[]string{"spec.config.master: Forbidden"},
+		),
+		Entry("unknown plugin types are not checked",
+			`{"cniVersion": "0.3.1", "type": "some-plugin", "whatever": true}`,
+			[]string{},
+		),
+		Entry("macvlan with invalid mac",
+			`{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth0", "mac": "c2:11:22"}`,
+			[]string{"spec.config.mac: Invalid value"},
+		),
+	)
+
+	// the example configs of the documentation of the reference plugins
+	DescribeTable("Upstream example configurations",
+		func(config string) {
+			Expect(validatePluginConfs([]byte(config))).To(BeEmpty())
+		},
+		Entry("macvlan", `{"name": "mynet", "type": "macvlan", "master": "eth0", "linkInContainer": false, "ipam": {"type": "dhcp"}}`),
+		Entry("macvlan with mac", `{"cniVersion": "1.0.0", "name": "mynet", "type": "macvlan", "master": "eth0", "mode": "bridge", "mac": "c2:11:22:33:44:55",
+			"bcqueuelen": 1000, "ipam": {"type": "host-local", "subnet": "10.1.2.0/24"}}`),
+		Entry("ipvlan", `{"name": "mynet", "type": "ipvlan", "master": "eth0", "ipam": {"type": "host-local", "subnet": "10.1.2.0/24"}}`),
+		Entry("bridge", `{"cniVersion": "0.3.1", "name": "mynet", "type": "bridge", "bridge": "mynet0", "isDefaultGateway": true, "forceAddress": false,
+			"ipMasq": true, "hairpinMode": true, "ipam": {"type": "host-local", "subnet": "10.10.0.0/16"}}`),
+		Entry("bridge with vlan trunk", `{"cniVersion": "0.3.1", "name": "mynet", "type": "bridge", "bridge": "mynet0",
+			"vlanTrunk": [{"id": 101}, {"minID": 200, "maxID": 299}], "ipam": {"type": "host-local", "subnet": "10.10.0.0/16"}}`),
+		Entry("host-device", `{"cniVersion": "0.3.1", "type": "host-device", "device": "enp0s1"}`),
+		Entry("vlan", `{"name": "mynet", "cniVersion": "0.3.1", "type": "vlan", "master": "eth0", "mtu": 1500, "vlanId": 5, "linkInContainer": false,
+			"ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}, "dns": {"nameservers": ["10.1.1.1", "8.8.8.8"]}}`),
+		Entry("ptp", `{"name": "mynet", "type": "ptp", "ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}, "dns": {"nameservers": ["10.1.1.1", "8.8.8.8"]}}`),
+		Entry("loopback", `{"cniVersion": "0.3.1", "name": "lo", "type": "loopback"}`),
+		Entry("static IPAM", `{"cniVersion": "0.3.1", "name": "mynet", "type": "macvlan", "master": "foo0",
+			"ipam": {"type": "static", "addresses": [{"address": "10.10.0.1/24", "gateway": "10.10.0.254"}, {"address": "3ffe:ffff:0:01ff::1/64", "gateway": "3ffe:ffff:0::1"}],
+			"routes": [{"dst": "0.0.0.0/0"}, {"dst": "192.168.0.0/16", "gw": "10.10.5.1"}, {"dst": "3ffe:ffff:0:01ff::1/64"}],
+			"dns": {"nameservers": ["8.8.8.8"], "domain": "example.com", "search": ["example.com"]}}}`),
+		Entry("host-local IPAM ranges", `{"cniVersion": "0.3.1", "name": "mynet", "type": "ipvlan", "master": "foo0",
+			"ipam": {"type": "host-local", "ranges": [[{"subnet": "10.10.0.0/16", "rangeStart": "10.10.1.20", "rangeEnd": "10.10.3.50", "gateway": "10.10.0.254"}, {"subnet": "172.16.5.0/24"}],
+			[{"subnet": "3ffe:ffff:0:01ff::/64", "rangeStart": "3ffe:ffff:0:01ff::0010", "rangeEnd": "3ffe:ffff:0:01ff::0020"}]],
+			"routes": [{"dst": "0.0.0.0/0"}, {"dst": "192.168.0.0/16", "gw": "10.10.5.1"}, {"dst": "3ffe:ffff:0:01ff::1/64"}], "dataDir": "/run/my-orchestrator/container-ipam-state"}}`),
+		Entry("conflist", `{"cniVersion": "1.0.0", "name": "mynet", "plugins": [
+			{"type": "bridge", "bridge": "cni0", "isGateway": true, "ipMasq": true, "ipam": {"type": "host-local", "subnet": "10.22.0.0/16", "routes": [{"dst": "0.0.0.0/0"}]}},
+			{"type": "tuning", "sysctl": {"net.ipv4.conf.IFNAME.arp_filter": "1"}},
+			{"type": "portmap", "capabilities": {"portMappings": true}}]}`),
+	)
+
+	It("should report every plugin problem from the NAD validation", func() {
+		nad := netv1.NetworkAttachmentDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "macvlan-net"},
+			Spec: netv1.NetworkAttachmentDefinitionSpec{
+				Config: `{"cniVersion": "0.3.1", "type": "macvlan", "mode": "brige", "mtu": -1}`,
+			},
+		}
+		allowed, _, err := validateNetworkAttachmentDefinition(nad, authenticationv1.UserInfo{})
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.config.mode"))
+		Expect(err.Error()).To(ContainSubstring("spec.config.mtu"))
+	})
+})
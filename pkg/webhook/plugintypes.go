@@ -0,0 +1,171 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// pluginPolicyTypeIndex indexes PluginTypePolicies by the plugin type they
+// restrict
+const pluginPolicyTypeIndex = "type"
+
+var (
+	pluginPolicyInformer cache.SharedIndexInformer
+	// pluginPolicyIndexer is the PluginTypePolicy cache; no plugin type is
+	// restricted while it is nil, and every NAD is denied while it is not
+	// synced yet
+	pluginPolicyIndexer cache.Indexer
+	pluginPolicySynced  cache.InformerSynced
+)
+
+// setupPluginPolicyInformer creates the PluginTypePolicy informer
+func setupPluginPolicyInformer(client rest.Interface) {
+	pluginPolicyInformer = cache.NewSharedIndexInformer(
+		cache.NewListWatchFromClient(client, "plugintypepolicies", metav1.NamespaceAll, fields.Everything()),
+		&admissionv1alpha1.PluginTypePolicy{},
+		informerResyncPeriod,
+		cache.Indexers{pluginPolicyTypeIndex: pluginPolicyTypeIndexFunc},
+	)
+	pluginPolicyIndexer = pluginPolicyInformer.GetIndexer()
+	pluginPolicySynced = pluginPolicyInformer.HasSynced
+}
+
+func pluginPolicyTypeIndexFunc(obj interface{}) ([]string, error) {
+	policy, ok := obj.(*admissionv1alpha1.PluginTypePolicy)
+	if !ok {
+		return nil, nil
+	}
+	return []string{policy.Spec.Type}, nil
+}
+
+// pluginFieldValue returns the value of the dot separated field of a plugin
+// config in the form FieldPredicate values are compared with
+func pluginFieldValue(raw map[string]interface{}, path string) string {
+	var value interface{} = raw
+	for _, key := range strings.Split(path, ".") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if value, ok = m[key]; !ok {
+			return ""
+		}
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// pluginPolicyMatches reports whether the plugin matches every predicate of
+// the policy
+func pluginPolicyMatches(policy *admissionv1alpha1.PluginTypePolicy, plugin pluginConf) bool {
+	for _, predicate := range policy.Spec.Match {
+		if !containsString(predicate.Values, pluginFieldValue(plugin.Raw, predicate.Field)) {
+			return false
+		}
+	}
+	return true
+}
+
+// pluginPolicyAllows reports whether the policy allows the user to create
+// net-attach-defs with the plugins it restricts in the namespace
+func pluginPolicyAllows(policy *admissionv1alpha1.PluginTypePolicy, namespace string, user authenticationv1.UserInfo) bool {
+	if containsString(policy.Spec.Namespaces, namespace) || containsString(policy.Spec.Users, user.Username) {
+		return true
+	}
+	for _, group := range user.Groups {
+		if containsString(policy.Spec.Groups, group) {
+			return true
+		}
+	}
+	if policy.Spec.NamespaceSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+	if err != nil {
+		glog.Warningf("ignoring invalid namespace selector of PluginTypePolicy %s: %v", policy.Name, err)
+		return false
+	}
+	ns := getNamespace(namespace)
+	if ns == nil {
+		return false
+	}
+	return selector.Matches(labels.Set(ns.Labels))
+}
+
+// validatePluginTypePolicies checks every plugin of the NAD config against
+// the PluginTypePolicies restricting its type. A plugin matched by policies is
+// only allowed if one of them allows the user or the NAD namespace.
+func validatePluginTypePolicies(confBytes []byte, namespace string, user authenticationv1.UserInfo) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if pluginPolicyIndexer == nil {
+		return allErrs
+	}
+	plugins, err := getPluginConfs(confBytes)
+	if err != nil {
+		return allErrs
+	}
+	if pluginPolicySynced != nil && !pluginPolicySynced() {
+		return append(allErrs, field.InternalError(field.NewPath("spec", "config"),
+			fmt.Errorf("PluginTypePolicy cache is not synced yet, retry later")))
+	}
+
+	for _, plugin := range plugins {
+		objs, err := pluginPolicyIndexer.ByIndex(pluginPolicyTypeIndex, plugin.Type)
+		if err != nil {
+			return append(allErrs, field.InternalError(plugin.Path.Child("type"), err))
+		}
+		var matching []string
+		allowed := false
+		for _, obj := range objs {
+			policy, ok := obj.(*admissionv1alpha1.PluginTypePolicy)
+			if !ok || !pluginPolicyMatches(policy, plugin) {
+				continue
+			}
+			matching = append(matching, policy.Name)
+			if pluginPolicyAllows(policy, namespace, user) {
+				allowed = true
+				break
+			}
+		}
+		if len(matching) == 0 || allowed {
+			continue
+		}
+		sort.Strings(matching)
+		allErrs = append(allErrs, field.Forbidden(plugin.Path.Child("type"),
+			fmt.Sprintf("%s plugins are restricted by PluginTypePolicy %s and user %s may not use them in namespace %s",
+				plugin.Type, strings.Join(matching, ", "), user.Username, namespace)))
+	}
+	return allErrs
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// usePluginPolicies replaces the PluginTypePolicy cache with one holding the
+// given policies
+func usePluginPolicies(policies ...*admissionv1alpha1.PluginTypePolicy) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{pluginPolicyTypeIndex: pluginPolicyTypeIndexFunc})
+	for _, policy := range policies {
+		Expect(indexer.Add(policy)).To(Succeed())
+	}
+	pluginPolicyIndexer = indexer
+	pluginPolicySynced = nil
+}
+
+var _ = Describe("Plugin type policies", func() {
+
+	BeforeEach(func() {
+		useNamespaces(map[string]map[string]string{
+			"infra":   nil,
+			"network": {"network-admin": "true"},
+			"team-a":  nil,
+		})
+		usePluginPolicies(
+			&admissionv1alpha1.PluginTypePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "host-device"},
+				Spec: admissionv1alpha1.PluginTypePolicySpec{
+					Type:       "host-device",
+					Namespaces: []string{"infra"},
+					Users:      []string{"alice"},
+				},
+			},
+			&admissionv1alpha1.PluginTypePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "macvlan-on-primary-nic"},
+				Spec: admissionv1alpha1.PluginTypePolicySpec{
+					Type:              "macvlan",
+					Match:             []admissionv1alpha1.FieldPredicate{{Field: "master", Values: []string{"", "eth0"}}},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"network-admin": "true"}},
+				},
+			},
+			&admissionv1alpha1.PluginTypePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "gateway-bridge"},
+				Spec: admissionv1alpha1.PluginTypePolicySpec{
+					Type:   "bridge",
+					Match:  []admissionv1alpha1.FieldPredicate{{Field: "isGateway", Values: []string{"true"}}},
+					Groups: []string{"system:masters"},
+				},
+			},
+			&admissionv1alpha1.PluginTypePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "gateway-bridge-infra"},
+				Spec: admissionv1alpha1.PluginTypePolicySpec{
+					Type:       "bridge",
+					Match:      []admissionv1alpha1.FieldPredicate{{Field: "isGateway", Values: []string{"true"}}},
+					Namespaces: []string{"infra"},
+				},
+			},
+		)
+	})
+
+	AfterEach(func() {
+		pluginPolicyIndexer = nil
+		namespaceIndexer = nil
+	})
+
+	DescribeTable("net-attach-def creation",
+		func(namespace string, user authenticationv1.UserInfo, config string, message string) {
+			allowed, _, err := validateNetworkAttachmentDefinition(*newNetAttachDef(namespace, "my-net", config), user)
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("unrestricted plugin type", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "ipvlan", "master": "eth0"}`, ""),
+		Entry("restricted type in an allowed namespace", "infra", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "host-device", "device": "eth1"}`, ""),
+		Entry("restricted type by an allowed user", "team-a", authenticationv1.UserInfo{Username: "alice"},
+			`{"cniVersion": "0.3.1", "type": "host-device", "device": "eth1"}`, ""),
+		Entry("restricted type", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "host-device", "device": "eth1"}`,
+			"spec.config.type: Forbidden: host-device plugins are restricted by PluginTypePolicy host-device and user bob may not use them in namespace team-a"),
+		Entry("field predicate not matching", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1"}`, ""),
+		Entry("field predicate matching", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth0"}`, "restricted by PluginTypePolicy macvlan-on-primary-nic"),
+		Entry("field predicate matching a missing field", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "macvlan"}`, "restricted by PluginTypePolicy macvlan-on-primary-nic"),
+		Entry("namespace selected", "network", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth0"}`, ""),
+		Entry("boolean predicate not matching", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "bridge", "bridge": "br0", "isGateway": false}`, ""),
+		Entry("allowed group", "team-a", authenticationv1.UserInfo{Username: "bob", Groups: []string{"system:authenticated", "system:masters"}},
+			`{"cniVersion": "0.3.1", "type": "bridge", "bridge": "br0", "isGateway": true}`, ""),
+		Entry("several matching policies", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "type": "bridge", "bridge": "br0", "isGateway": true}`,
+			"restricted by PluginTypePolicy gateway-bridge, gateway-bridge-infra"),
+		Entry("any plugin of a conflist", "team-a", authenticationv1.UserInfo{Username: "bob"},
+			`{"cniVersion": "0.3.1", "name": "my-net", "plugins": [{"type": "ipvlan", "master": "eth1"}, {"type": "host-device", "device": "eth1"}]}`,
+			"spec.config.plugins[1].type: Forbidden: host-device plugins are restricted"),
+	)
+
+	It("should deny net-attach-defs while the policies are not synced", func() {
+		pluginPolicySynced = func() bool { return false }
+		allowed, _, err := validateNetworkAttachmentDefinition(*newNetAttachDef("team-a", "my-net", `{"cniVersion": "0.3.1", "type": "ipvlan", "master": "eth1"}`), authenticationv1.UserInfo{})
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("PluginTypePolicy cache is not synced yet")))
+	})
+})
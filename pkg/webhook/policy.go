@@ -0,0 +1,51 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Policies of the optional checks, i.e. what the webhook does with a
+// request failing the check
+const (
+	// PolicyDeny rejects the request
+	PolicyDeny = "deny"
+	// PolicyWarn admits the request with an admission warning
+	PolicyWarn = "warn"
+	// PolicyIgnore disables the check
+	PolicyIgnore = "ignore"
+)
+
+// parsePolicy checks policy is one of the known check policies
+func parsePolicy(check, policy string) (string, error) {
+	switch policy {
+	case PolicyDeny, PolicyWarn, PolicyIgnore:
+		return policy, nil
+	}
+	return "", errors.Errorf("invalid %s policy '%s', must be one of %s, %s or %s", check, policy, PolicyDeny, PolicyWarn, PolicyIgnore)
+}
+
+// applyPolicy turns the message of a failed check into an error or a
+// warning according to policy
+func applyPolicy(policy, msg string) ([]string, error) {
+	switch policy {
+	case PolicyDeny:
+		return nil, errors.New(msg)
+	case PolicyWarn:
+		return []string{msg}, nil
+	}
+	return nil, nil
+}
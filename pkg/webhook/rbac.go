@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// networkUseVerb is the RBAC verb on network-attachment-definitions
+	// allowing a user to attach pods to them from other namespaces
+	networkUseVerb = "use"
+
+	netAttachDefGroup    = "k8s.cni.cncf.io"
+	netAttachDefResource = "network-attachment-definitions"
+
+	defaultServiceAccount = "default"
+)
+
+// networkUseReviewer reports whether the user may use the net-attach-def
+// namespace/name; it is replaced in tests
+var networkUseReviewer = reviewNetworkUse
+
+// authorizePodServiceAccount is whether RBAC may also allow the use of a
+// net-attach-def through the service account a pod runs as
+var authorizePodServiceAccount = false
+
+// SetAuthorizePodServiceAccount sets whether the service account a pod runs
+// as may be granted the use of net-attach-defs of other namespaces, besides
+// the user creating the pod
+func SetAuthorizePodServiceAccount(authorize bool) {
+	authorizePodServiceAccount = authorize
+}
+
+// networkUsers returns the users whose RBAC permissions let a pod use
+// net-attach-defs of other namespaces: the user creating the pod and, if
+// enabled, the service account the pod runs as. Pods of workloads are
+// created by the service accounts of the workload controllers, which a role
+// binding to the service account of the pods can then stand in for; anyone
+// who may create pods in the namespace inherits the use of the net-attach-def
+// through any service account bound there.
+func networkUsers(user authenticationv1.UserInfo, podNamespace, serviceAccount string) []authenticationv1.UserInfo {
+	if !authorizePodServiceAccount {
+		return []authenticationv1.UserInfo{user}
+	}
+	if serviceAccount == "" {
+		serviceAccount = defaultServiceAccount
+	}
+	username := fmt.Sprintf("system:serviceaccount:%s:%s", podNamespace, serviceAccount)
+	if user.Username == username {
+		return []authenticationv1.UserInfo{user}
+	}
+	return []authenticationv1.UserInfo{user, {
+		Username: username,
+		Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:" + podNamespace, "system:authenticated"},
+	}}
+}
+
+// networkUseAllowed reports whether one of the users may use the
+// net-attach-def namespace/name
+func networkUseAllowed(users []authenticationv1.UserInfo, namespace, name string) (bool, error) {
+	for _, user := range users {
+		allowed, err := networkUseReviewer(user, namespace, name)
+		if err != nil || allowed {
+			return allowed, err
+		}
+	}
+	return false, nil
+}
+
+// describeNetworkUsers names the users in a denial message
+func describeNetworkUsers(users []authenticationv1.UserInfo) string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = "user " + user.Username
+	}
+	if len(names) == 1 {
+		return names[0] + " is not"
+	}
+	return "neither " + strings.Join(names, " nor ") + " is"
+}
+
+// reviewNetworkUse issues a SubjectAccessReview of the use verb on the
+// net-attach-def namespace/name for the user
+func reviewNetworkUse(user authenticationv1.UserInfo, namespace, name string) (bool, error) {
+	if clientset == nil {
+		return false, nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      networkUseVerb,
+				Group:     netAttachDefGroup,
+				Resource:  netAttachDefResource,
+				Name:      name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			Extra:  extra,
+			UID:    user.UID,
+		},
+	}
+	result, err := clientset.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to review the use of net-attach-def %s/%s by %s", namespace, name, user.Username)
+	}
+	if !result.Status.Allowed {
+		glog.Infof("user %s may not use net-attach-def %s/%s: %s", user.Username, namespace, name, result.Status.Reason)
+	}
+	return result.Status.Allowed, nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// replicaSetController is the user the replicaset controller creates pods as
+var replicaSetController = authenticationv1.UserInfo{
+	Username: "system:serviceaccount:kube-system:replicaset-controller",
+	Groups:   []string{"system:serviceaccounts", "system:serviceaccounts:kube-system", "system:authenticated"},
+}
+
+// newServiceAccountPodAdmissionReview returns the review of a request of the
+// user creating a pod running as the service account
+func newServiceAccountPodAdmissionReview(user authenticationv1.UserInfo, serviceAccount, networks string) *admissionv1.AdmissionReview {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "team-a", Annotations: map[string]string{networksAnnotationKey: networks}},
+		Spec:       v1.PodSpec{ServiceAccountName: serviceAccount},
+	}
+	raw, err := json.Marshal(pod)
+	Expect(err).NotTo(HaveOccurred())
+	ar := newPodAdmissionReview("team-a", nil)
+	ar.Request.Object.Raw = raw
+	ar.Request.UserInfo = user
+	return ar
+}
+
+var _ = Describe("RBAC authorization of cross-namespace networks", func() {
+
+	var reviewed []string
+
+	BeforeEach(func() {
+		reviewed = nil
+		useGrants()
+		// alice and the storage-client service account of team-a may use
+		// infra/storage-net, members of the net-admins group any
+		// net-attach-def of infra
+		networkUseReviewer = func(user authenticationv1.UserInfo, namespace, name string) (bool, error) {
+			reviewed = append(reviewed, fmt.Sprintf("%s:%s/%s", user.Username, namespace, name))
+			if namespace == "broken" {
+				return false, fmt.Errorf("connection refused")
+			}
+			if (user.Username == "alice" || user.Username == "system:serviceaccount:team-a:storage-client") && namespace == "infra" && name == "storage-net" {
+				return true, nil
+			}
+			for _, group := range user.Groups {
+				if group == "net-admins" && namespace == "infra" {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	})
+
+	AfterEach(func() {
+		networkUseReviewer = reviewNetworkUse
+		grantIndexer = nil
+		SetAuthorizePodServiceAccount(false)
+	})
+
+	DescribeTable("pod network references",
+		func(user authenticationv1.UserInfo, networks string, message string) {
+			ar := newPodAdmissionReview("team-a", map[string]string{networksAnnotationKey: networks})
+			ar.Request.UserInfo = user
+			allowed, _, err := analyzeIsolationAnnotation(ar)
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("allowed by user", authenticationv1.UserInfo{Username: "alice"}, "infra/storage-net", ""),
+		Entry("allowed by group", authenticationv1.UserInfo{Username: "bob", Groups: []string{"net-admins"}}, "infra/admin-net", ""),
+		Entry("other network of the namespace", authenticationv1.UserInfo{Username: "alice"}, "infra/admin-net",
+			"attachment 1 (infra/admin-net): no NetworkAttachmentGrant grants namespace team-a the use of net-attach-defs of namespace infra, and user alice is not allowed to use it"),
+		Entry("user without role", authenticationv1.UserInfo{Username: "bob"}, "macvlan-conf,infra/storage-net",
+			"attachment 2 (infra/storage-net): no NetworkAttachmentGrant grants namespace team-a"),
+		Entry("failing review", authenticationv1.UserInfo{Username: "alice"}, "broken/storage-net", "connection refused"),
+	)
+
+	It("should only review references to other namespaces", func() {
+		ar := newPodAdmissionReview("team-a", map[string]string{networksAnnotationKey: "macvlan-conf,team-a/other-conf,infra/storage-net"})
+		ar.Request.UserInfo = authenticationv1.UserInfo{Username: "alice"}
+		allowed, _, err := analyzeIsolationAnnotation(ar)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(reviewed).To(Equal([]string{"alice:infra/storage-net"}))
+	})
+
+	It("should only review the user creating the pod by default", func() {
+		allowed, _, err := analyzeIsolationAnnotation(newServiceAccountPodAdmissionReview(replicaSetController, "storage-client", "infra/storage-net"))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("and user system:serviceaccount:kube-system:replicaset-controller is not allowed to use it")))
+		Expect(reviewed).To(Equal([]string{"system:serviceaccount:kube-system:replicaset-controller:infra/storage-net"}))
+	})
+
+	Context("with the service account of the pod authorized", func() {
+
+		BeforeEach(func() {
+			SetAuthorizePodServiceAccount(true)
+		})
+
+		DescribeTable("pods created by a controller",
+			func(serviceAccount string, message string) {
+				allowed, _, err := analyzeIsolationAnnotation(newServiceAccountPodAdmissionReview(replicaSetController, serviceAccount, "infra/storage-net"))
+				if message == "" {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(allowed).To(BeTrue())
+				} else {
+					Expect(allowed).To(BeFalse())
+					Expect(err).To(MatchError(ContainSubstring(message)))
+				}
+			},
+			Entry("allowed by the pod service account", "storage-client", ""),
+			Entry("default service account", "",
+				"neither user system:serviceaccount:kube-system:replicaset-controller nor user system:serviceaccount:team-a:default is allowed to use it"),
+		)
+
+		It("should review the service account of pod templates", func() {
+			template := podTemplate("infra/storage-net")
+			template.Spec.ServiceAccountName = "storage-client"
+			ar := newWorkloadAdmissionReview(deploymentKind, &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "team-a"},
+				Spec:       appsv1.DeploymentSpec{Template: template},
+			})
+			ar.Request.Namespace = "team-a"
+			ar.Request.UserInfo = authenticationv1.UserInfo{Username: "bob"}
+			allowed, _, err := analyzeIsolationAnnotation(ar)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+			Expect(reviewed).To(Equal([]string{"bob:infra/storage-net", "system:serviceaccount:team-a:storage-client:infra/storage-net"}))
+		})
+
+		It("should review a pod created by its own service account once", func() {
+			user := authenticationv1.UserInfo{Username: "system:serviceaccount:team-a:other"}
+			allowed, _, err := analyzeIsolationAnnotation(newServiceAccountPodAdmissionReview(user, "other", "infra/storage-net"))
+			Expect(allowed).To(BeFalse())
+			Expect(err).To(MatchError(ContainSubstring("and user system:serviceaccount:team-a:other is not allowed to use it")))
+			Expect(reviewed).To(Equal([]string{"system:serviceaccount:team-a:other:infra/storage-net"}))
+		})
+	})
+
+	It("should deny when no client can review the access", func() {
+		networkUseReviewer = reviewNetworkUse
+		ar := newPodAdmissionReview("team-a", map[string]string{networksAnnotationKey: "infra/storage-net"})
+		ar.Request.UserInfo = authenticationv1.UserInfo{Username: "alice"}
+		allowed, _, err := analyzeIsolationAnnotation(ar)
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(HaveOccurred())
+	})
+})
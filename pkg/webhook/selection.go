@@ -0,0 +1,226 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const maxPort = 65535
+
+var (
+	portMappingProtocols = []string{"tcp", "udp", "sctp"}
+
+	infinibandGUIDRegex = regexp.MustCompile(`^([0-9a-fA-F]{2}:){7}[0-9a-fA-F]{2}$`)
+)
+
+// parseIPRequest parses an 'ips' entry, which is either a plain IP address
+// or an IP address in CIDR notation
+func parseIPRequest(s string) (net.IP, error) {
+	if strings.Contains(s, "/") {
+		ip, _, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, errors.Errorf("'%s' is not a valid IP address in CIDR notation", s)
+		}
+		return ip, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, errors.Errorf("'%s' is not a valid IP address", s)
+	}
+	return ip, nil
+}
+
+// validateNetworkSelectionElement checks the fields of a network selection
+// element of the JSON form of the annotation
+func validateNetworkSelectionElement(network *types.NetworkSelectionElement) []string {
+	var problems []string
+
+	if network.Name == "" {
+		problems = append(problems, "name: must not be empty")
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(network.Name) {
+			problems = append(problems, fmt.Sprintf("name: '%s' is not a valid net-attach-def name: %s", network.Name, msg))
+		}
+	}
+	if network.Namespace != "" && network.Namespace != namespaceConstraint {
+		for _, msg := range validation.IsDNS1123Label(network.Namespace) {
+			problems = append(problems, fmt.Sprintf("namespace: '%s' is not a valid namespace name: %s", network.Namespace, msg))
+		}
+	}
+
+	families := map[bool]bool{}
+	seen := map[string]bool{}
+	for _, s := range network.IPRequest {
+		ip, err := parseIPRequest(s)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("ips: %v", err))
+			continue
+		}
+		if ip.IsUnspecified() || ip.IsMulticast() || ip.IsLoopback() {
+			problems = append(problems, fmt.Sprintf("ips: '%s' is not a unicast address", s))
+		}
+		if seen[ip.String()] {
+			problems = append(problems, fmt.Sprintf("ips: '%s' is requested more than once", s))
+		}
+		seen[ip.String()] = true
+		families[isIPv4(ip)] = true
+	}
+
+	if network.MacRequest != "" {
+		mac, err := net.ParseMAC(network.MacRequest)
+		if err != nil || len(mac) != 6 {
+			problems = append(problems, fmt.Sprintf("mac: '%s' is not a valid MAC address", network.MacRequest))
+		} else if mac[0]&0x01 != 0 {
+			problems = append(problems, fmt.Sprintf("mac: '%s' is not a unicast MAC address", network.MacRequest))
+		} else if strings.Trim(mac.String(), "0:") == "" {
+			problems = append(problems, fmt.Sprintf("mac: '%s' is not a valid MAC address", network.MacRequest))
+		}
+	}
+
+	if network.InfinibandGUIDRequest != "" && !infinibandGUIDRegex.MatchString(network.InfinibandGUIDRequest) {
+		problems = append(problems, fmt.Sprintf("infiniband-guid: '%s' must be 8 colon-separated hex bytes", network.InfinibandGUIDRequest))
+	}
+
+	if network.GatewayRequest != nil {
+		gwFamilies := map[bool]bool{}
+		for _, gw := range *network.GatewayRequest {
+			if gw == nil || gw.IsUnspecified() {
+				problems = append(problems, "default-route: gateways must be valid IP addresses")
+				continue
+			}
+			v4 := isIPv4(gw)
+			if gwFamilies[v4] {
+				problems = append(problems, fmt.Sprintf("default-route: more than one %s gateway", ipFamilyName(v4)))
+			}
+			gwFamilies[v4] = true
+			if len(families) > 0 && !families[v4] {
+				problems = append(problems, fmt.Sprintf("default-route: gateway %s does not match the address family of the requested ips", gw))
+			}
+		}
+	}
+
+	for i, pm := range network.PortMappingsRequest {
+		if pm == nil {
+			continue
+		}
+		if pm.HostPort < 1 || pm.HostPort > maxPort {
+			problems = append(problems, fmt.Sprintf("portMappings[%d]: hostPort %d must be between 1 and %d", i, pm.HostPort, maxPort))
+		}
+		if pm.ContainerPort < 1 || pm.ContainerPort > maxPort {
+			problems = append(problems, fmt.Sprintf("portMappings[%d]: containerPort %d must be between 1 and %d", i, pm.ContainerPort, maxPort))
+		}
+		if pm.Protocol != "" && !containsString(portMappingProtocols, strings.ToLower(pm.Protocol)) {
+			problems = append(problems, fmt.Sprintf("portMappings[%d]: protocol '%s' must be one of %s", i, pm.Protocol, strings.Join(portMappingProtocols, ", ")))
+		}
+		if pm.HostIP != "" && net.ParseIP(pm.HostIP) == nil {
+			problems = append(problems, fmt.Sprintf("portMappings[%d]: hostIP '%s' is not a valid IP address", i, pm.HostIP))
+		}
+	}
+
+	if bw := network.BandwidthRequest; bw != nil {
+		problems = append(problems, validateRateAndBurst("ingress", bw.IngressRate, bw.IngressBurst)...)
+		problems = append(problems, validateRateAndBurst("egress", bw.EgressRate, bw.EgressBurst)...)
+	}
+
+	return problems
+}
+
+// validateCNIArgs checks the cni-args of every element of the JSON form of
+// the annotation is a JSON object, which Multus passes on to the plugins
+func validateCNIArgs(podNetworks string) error {
+	var elements []map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(podNetworks), &elements); err != nil {
+		// not a list of objects, which parsing the annotation reports
+		return nil
+	}
+	for i, element := range elements {
+		raw, ok := element["cni-args"]
+		if !ok {
+			continue
+		}
+		var args interface{}
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return err
+		}
+		if _, isObject := args.(map[string]interface{}); !isObject && args != nil {
+			return errors.Errorf("network selection element %d: cni-args must be a JSON object, not %s", i+1, raw)
+		}
+	}
+	return nil
+}
+
+// validateRateAndBurst mirrors the checks of the bandwidth plugin
+func validateRateAndBurst(direction string, rate, burst int) []string {
+	switch {
+	case rate < 0 || burst < 0:
+		return []string{fmt.Sprintf("bandwidth: %s rate and burst must not be negative", direction)}
+	case rate != 0 && burst == 0:
+		return []string{fmt.Sprintf("bandwidth: %sBurst must be set together with %sRate", direction, direction)}
+	case rate == 0 && burst != 0:
+		return []string{fmt.Sprintf("bandwidth: %sRate must be set together with %sBurst", direction, direction)}
+	case burst/8 >= math.MaxUint32:
+		return []string{fmt.Sprintf("bandwidth: %sBurst must be less than %d bits", direction, uint64(math.MaxUint32)*8)}
+	}
+	return nil
+}
+
+// validateNetworkSelectionElements checks every network selection element
+// of a pod, and that at most one of them claims the default route
+func validateNetworkSelectionElements(annotation string, networks []*types.NetworkSelectionElement, podNamespace string) error {
+	var problems []string
+	var defaultRoute []string
+	for i, network := range networks {
+		for _, problem := range validateNetworkSelectionElement(network) {
+			problems = append(problems, fmt.Sprintf("%s: %s", describeAttachment(networks, i, podNamespace), problem))
+		}
+		if network.GatewayRequest != nil {
+			defaultRoute = append(defaultRoute, describeAttachment(networks, i, podNamespace))
+		}
+	}
+	if len(defaultRoute) > 1 {
+		problems = append(problems, fmt.Sprintf("default-route is claimed by more than one attachment: %s", strings.Join(defaultRoute, ", ")))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.Errorf("%s annotation has invalid network selection elements: %s", annotation, strings.Join(problems, "; "))
+}
+
+func ipFamilyName(v4 bool) string {
+	if v4 {
+		return "IPv4"
+	}
+	return "IPv6"
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Network selection element validation", func() {
+
+	DescribeTable("JSON form fields",
+		func(annotation string, message string) {
+			networks, err := parsePodNetworkAnnotation(annotation, namespaceConstraint)
+			Expect(err).NotTo(HaveOccurred())
+			err = validateNetworkSelectionElements(networksAnnotationKey, networks, "default")
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			}
+		},
+		Entry("every field valid",
+			`[{"name": "net-a", "ips": ["10.1.1.10/24", "fd00::10"], "mac": "02:23:45:67:89:01", "default-route": ["10.1.1.1"],
+			  "portMappings": [{"hostPort": 8080, "containerPort": 80, "protocol": "TCP"}],
+			  "bandwidth": {"ingressRate": 1000, "ingressBurst": 2000}, "infiniband-guid": "c2:11:22:33:44:55:66:77"}]`,
+			"",
+		),
+		Entry("invalid IP", `[{"name": "net-a", "ips": ["10.1.1.300"]}]`, "attachment 1 (default/net-a): ips: '10.1.1.300' is not a valid IP address"),
+		Entry("invalid CIDR", `[{"name": "net-a", "ips": ["10.1.1.3/33"]}]`, "is not a valid IP address in CIDR notation"),
+		Entry("duplicate IP", `[{"name": "net-a", "ips": ["10.1.1.3/24", "10.1.1.3"]}]`, "is requested more than once"),
+		Entry("multicast MAC", `[{"name": "net-a", "mac": "01:00:5e:00:00:01"}]`, "is not a unicast MAC address"),
+		Entry("malformed MAC", `[{"name": "net-a", "mac": "02:23:45:67:89"}]`, "is not a valid MAC address"),
+		Entry("zero MAC", `[{"name": "net-a", "mac": "00:00:00:00:00:00"}]`, "is not a valid MAC address"),
+		Entry("malformed GUID", `[{"name": "net-a", "infiniband-guid": "c2:11:22:33:44:55"}]`, "infiniband-guid"),
+		Entry("default route claimed twice", `[{"name": "net-a", "default-route": ["10.1.1.1"]}, {"name": "net-b", "default-route": ["10.2.1.1"]}]`,
+			"default-route is claimed by more than one attachment: attachment 1 (default/net-a), attachment 2 (default/net-b)"),
+		Entry("gateway of another family", `[{"name": "net-a", "ips": ["10.1.1.10/24"], "default-route": ["fd00::1"]}]`, "does not match the address family"),
+		Entry("two gateways of the same family", `[{"name": "net-a", "default-route": ["10.1.1.1", "10.1.1.2"]}]`, "more than one IPv4 gateway"),
+		Entry("out of range ports", `[{"name": "net-a", "portMappings": [{"hostPort": 0, "containerPort": 70000}]}]`, "portMappings[0]: hostPort 0 must be between 1 and 65535"),
+		Entry("unknown protocol", `[{"name": "net-a", "portMappings": [{"hostPort": 80, "containerPort": 80, "protocol": "icmp"}]}]`, "protocol 'icmp'"),
+		Entry("invalid host IP", `[{"name": "net-a", "portMappings": [{"hostPort": 80, "containerPort": 80, "hostIP": "localhost"}]}]`, "hostIP 'localhost'"),
+		Entry("rate without burst", `[{"name": "net-a", "bandwidth": {"egressRate": 1000}}]`, "egressBurst must be set together with egressRate"),
+		Entry("negative rate", `[{"name": "net-a", "bandwidth": {"ingressRate": -1, "ingressBurst": 10}}]`, "must not be negative"),
+		Entry("missing name", `[{"namespace": "infra", "ips": ["10.1.1.10/24"]}]`, "attachment 1 (infra/): name: must not be empty"),
+		Entry("invalid name", `[{"name": "Net_A"}]`, "name: 'Net_A' is not a valid net-attach-def name"),
+		Entry("dotted name", `[{"name": "net.a"}]`, ""),
+		Entry("invalid namespace", `[{"name": "net-a", "namespace": "infra.net"}]`, "namespace: 'infra.net' is not a valid namespace name"),
+		Entry("cni-args object", `[{"name": "net-a", "cni-args": {"foo": "bar"}}]`, ""),
+	)
+
+	DescribeTable("JSON form cni-args",
+		func(annotation string, message string) {
+			_, err := parsePodNetworkAnnotation(annotation, namespaceConstraint)
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			}
+		},
+		Entry("object", `[{"name": "net-a", "cni-args": {"foo": "bar"}}]`, ""),
+		Entry("null", `[{"name": "net-a", "cni-args": null}]`, ""),
+		Entry("string", `[{"name": "net-a"}, {"name": "net-b", "cni-args": "foo=bar"}]`, "network selection element 2: cni-args must be a JSON object, not \"foo=bar\""),
+		Entry("list", `[{"name": "net-a", "cni-args": ["foo"]}]`, "cni-args must be a JSON object"),
+	)
+})
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/golang/glog"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+	v1 "k8s.io/api/core/v1"
+)
+
+var staticIPPolicy = PolicyDeny
+
+// SetStaticIPPolicy sets how pods requesting static IPs outside the subnets
+// of the NAD, inside its dynamic allocation ranges or already in use are
+// handled
+func SetStaticIPPolicy(policy string) error {
+	p, err := parsePolicy("static IP", policy)
+	if err != nil {
+		return err
+	}
+	staticIPPolicy = p
+	return nil
+}
+
+// checkRequestedIP checks a requested IP against the host-local ranges of a
+// NAD. Addresses of a subnet outside its rangeStart-rangeEnd pool are left for
+// static assignment; a range setting neither bound allocates its whole subnet
+// dynamically.
+func checkRequestedIP(ip net.IP, ranges []*ipamRange) string {
+	if len(ranges) == 0 {
+		return ""
+	}
+	var subnets []string
+	inSubnet := false
+	for _, r := range ranges {
+		subnets = append(subnets, r.Subnet.String())
+		if !r.Subnet.Contains(ip) {
+			continue
+		}
+		inSubnet = true
+		if r.contains(ip) {
+			return fmt.Sprintf("is within the dynamic allocation range %s-%s", r.firstIP(), r.lastIP())
+		}
+	}
+	if !inSubnet {
+		return fmt.Sprintf("is outside the configured subnets (%s)", strings.Join(subnets, ", "))
+	}
+	return ""
+}
+
+// usedIPs maps the IPs the running pods other than namespace/podName report
+// in their network status for the NAD to the pod using them
+func usedIPs(netAttachDef *netv1.NetworkAttachmentDefinition, namespace, podName string) (map[string]string, error) {
+	pods, ready, err := podsUsingNetwork(netAttachDef.Namespace, netAttachDef.Name)
+	if err != nil || !ready {
+		return nil, err
+	}
+
+	networkName := netAttachDef.Namespace + "/" + netAttachDef.Name
+	used := map[string]string{}
+	for _, pod := range pods {
+		if pod.Namespace == namespace && pod.Name == podName {
+			continue
+		}
+		for _, status := range podNetworkStatus(pod) {
+			if status.Name != networkName {
+				continue
+			}
+			for _, s := range status.IPs {
+				if ip := net.ParseIP(s); ip != nil {
+					used[ip.String()] = pod.Namespace + "/" + pod.Name
+				}
+			}
+		}
+	}
+	return used, nil
+}
+
+// podNetworkStatus returns the network status multus reported on the pod
+func podNetworkStatus(pod *v1.Pod) []netv1.NetworkStatus {
+	annotation, ok := pod.Annotations[netv1.NetworkStatusAnnot]
+	if !ok {
+		return nil
+	}
+	var statuses []netv1.NetworkStatus
+	if err := json.Unmarshal([]byte(annotation), &statuses); err != nil {
+		glog.Infof("ignoring invalid %s annotation of pod %s/%s: %v", netv1.NetworkStatusAnnot, pod.Namespace, pod.Name, err)
+		return nil
+	}
+	return statuses
+}
+
+// checkStaticIPs verifies the IPs the pod requests on each network are within
+// the subnets of the NAD, outside its dynamic allocation ranges and not used
+// by another running pod. The in-use check is skipped for pod templates,
+// given an empty podName, as the running pods of the workload use the IPs.
+// Depending on the static IP policy the problems are returned as an error or
+// as warnings.
+func checkStaticIPs(networks []*types.NetworkSelectionElement, podNamespace, podName string) ([]string, error) {
+	if staticIPPolicy == PolicyIgnore || len(networks) == 0 {
+		return nil, nil
+	}
+	if !netAttachDefCacheReady() {
+		glog.Warning("net-attach-def cache is not ready, skipping static IP check")
+		return nil, nil
+	}
+
+	var problems []string
+	for i, network := range networks {
+		if len(network.IPRequest) == 0 {
+			continue
+		}
+		netAttachDef, err := getNetAttachDef(resolveNetworkNamespace(network, podNamespace), network.Name)
+		if err != nil {
+			return nil, err
+		}
+		if netAttachDef == nil || netAttachDef.Spec.Config == "" {
+			continue
+		}
+		ranges := getHostLocalRanges([]byte(netAttachDef.Spec.Config))
+		var used map[string]string
+		if podName != "" {
+			used, err = usedIPs(netAttachDef, podNamespace, podName)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		attachment := describeAttachment(networks, i, podNamespace)
+		for _, s := range network.IPRequest {
+			ip, err := parseIPRequest(s)
+			if err != nil {
+				continue
+			}
+			if problem := checkRequestedIP(ip, ranges); problem != "" {
+				problems = append(problems, fmt.Sprintf("%s: ip %s %s", attachment, s, problem))
+			}
+			if pod, ok := used[ip.String()]; ok {
+				problems = append(problems, fmt.Sprintf("%s: ip %s is already used by pod %s", attachment, s, pod))
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil, nil
+	}
+
+	msg := fmt.Sprintf("%s annotation requests unusable static IPs: %s", networksAnnotationKey, strings.Join(problems, "; "))
+	glog.Info(msg)
+	return applyPolicy(staticIPPolicy, msg)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// usePods makes the pod cache return the given pods for every NAD
+func usePods(pods ...*v1.Pod) {
+	SetNetworkPodLister(func(namespace, name string) ([]*v1.Pod, bool, error) {
+		return pods, true, nil
+	})
+}
+
+func newRunningPod(namespace, name, networkStatus string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Annotations: map[string]string{"k8s.v1.cni.cncf.io/network-status": networkStatus},
+		},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+var _ = Describe("Static IP check", func() {
+
+	BeforeEach(func() {
+		useNetAttachDefs(
+			newNetAttachDef("default", "pool-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "capabilities": {"ips": true},
+				"ipam": {"type": "host-local", "ranges": [[{"subnet": "10.1.1.0/24", "rangeStart": "10.1.1.100", "rangeEnd": "10.1.1.199"}], [{"subnet": "fd00::/64"}]]}}`),
+			newNetAttachDef("default", "static-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "capabilities": {"ips": true}, "ipam": {"type": "static"}}`),
+		)
+		usePods(newRunningPod("default", "other-pod", `[{"name": "default/pool-conf", "interface": "net1", "ips": ["10.1.1.20"]}, {"name": "default/static-conf", "interface": "net2", "ips": ["10.9.9.9"]}]`))
+	})
+
+	AfterEach(func() {
+		netAttachDefIndexer = nil
+		SetNetworkPodLister(nil)
+		Expect(SetStaticIPPolicy(PolicyDeny)).To(Succeed())
+	})
+
+	DescribeTable("requested IPs",
+		func(networks string, message string) {
+			allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: networks}))
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			} else {
+				Expect(allowed).To(BeFalse())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+			}
+		},
+		Entry("outside the pool", `[{"name": "pool-conf", "ips": ["10.1.1.10/24"]}]`, ""),
+		Entry("in a subnet without pool bounds", `[{"name": "pool-conf", "ips": ["fd00::10/64"]}]`,
+			"ip fd00::10/64 is within the dynamic allocation range fd00::-fd00::ffff:ffff:ffff:ffff"),
+		Entry("without host-local IPAM", `[{"name": "static-conf", "ips": ["192.168.0.10/24"]}]`, ""),
+		Entry("outside the subnets", `[{"name": "pool-conf", "ips": ["10.1.2.10/24"]}]`,
+			"attachment 1 (default/pool-conf): ip 10.1.2.10/24 is outside the configured subnets (10.1.1.0/24, fd00::/64)"),
+		Entry("inside the pool", `[{"name": "pool-conf", "ips": ["10.1.1.150"]}]`,
+			"ip 10.1.1.150 is within the dynamic allocation range 10.1.1.100-10.1.1.199"),
+		Entry("used by another pod", `[{"name": "pool-conf", "ips": ["10.1.1.20/24"]}]`,
+			"ip 10.1.1.20/24 is already used by pod default/other-pod"),
+		Entry("used by another pod on another network", `[{"name": "pool-conf", "ips": ["10.1.1.30/24"]}, {"name": "static-conf", "ips": ["10.9.9.9/24"]}]`,
+			"attachment 2 (default/static-conf): ip 10.9.9.9/24 is already used by pod default/other-pod"),
+	)
+
+	It("should not check pod templates against the IPs in use", func() {
+		ar := newWorkloadAdmissionReview(deploymentKind, newDeployment(`[{"name": "pool-conf", "ips": ["10.1.1.20/24"]}]`))
+		allowed, _, err := analyzeIsolationAnnotation(ar)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+
+		ar = newWorkloadAdmissionReview(deploymentKind, newDeployment(`[{"name": "pool-conf", "ips": ["10.1.1.150/24"]}]`))
+		allowed, _, err = analyzeIsolationAnnotation(ar)
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("is within the dynamic allocation range")))
+	})
+
+	It("should not report the IPs of the pod itself", func() {
+		usePods(newRunningPod("default", "test-pod", `[{"name": "default/pool-conf", "ips": ["10.1.1.20"]}]`))
+		allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: `[{"name": "pool-conf", "ips": ["10.1.1.20/24"]}]`}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should skip the in-use check while the pod cache is not ready", func() {
+		SetNetworkPodLister(func(namespace, name string) ([]*v1.Pod, bool, error) { return nil, false, nil })
+		allowed, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: `[{"name": "pool-conf", "ips": ["10.1.1.20/24"]}]`}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should only warn when configured to", func() {
+		Expect(SetStaticIPPolicy(PolicyWarn)).To(Succeed())
+		allowed, warnings, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: `[{"name": "pool-conf", "ips": ["10.1.1.150"]}]`}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(warnings).To(ConsistOf(ContainSubstring("dynamic allocation range")))
+	})
+})
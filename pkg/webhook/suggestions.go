@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const maxNetworkSuggestions = 3
+
+// permittedNamespaces returns the namespaces whose NADs a pod of
+// podNamespace may refer to
+func permittedNamespaces(podNamespace string) []string {
+	return append([]string{podNamespace}, grantedNamespaces(podNamespace)...)
+}
+
+// describeMissingNetwork describes a missing NAD reference along with hints
+// to fix it: the closest NAD names the pod may use, and the namespaces the
+// pod may use a NAD with that very name of
+func describeMissingNetwork(namespace, name, podNamespace string, users []authenticationv1.UserInfo) string {
+	desc := namespace + "/" + name
+
+	var hints []string
+	if suggestions := suggestNetworks(name, namespace, permittedNamespaces(podNamespace)); len(suggestions) > 0 {
+		hints = append(hints, fmt.Sprintf("did you mean %s?", strings.Join(suggestions, ", ")))
+	}
+	if elsewhere := namespacesWithNetwork(name, namespace, podNamespace, users); len(elsewhere) > 0 {
+		hints = append(hints, fmt.Sprintf("a net-attach-def with this name exists in namespace %s, not in %s", strings.Join(elsewhere, ", "), namespace))
+	}
+	if len(hints) == 0 {
+		return desc
+	}
+	return fmt.Sprintf("%s (%s)", desc, strings.Join(hints, "; "))
+}
+
+// suggestNetworks returns the NAD names of namespaces closest to name by
+// edit distance, closest first. Names in another namespace than the resolved
+// namespace are qualified with their namespace.
+func suggestNetworks(name, resolvedNamespace string, namespaces []string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	var candidates []candidate
+	for _, ns := range namespaces {
+		objs, err := netAttachDefIndexer.ByIndex(cache.NamespaceIndex, ns)
+		if err != nil {
+			continue
+		}
+		for _, obj := range objs {
+			netAttachDef := obj.(*netv1.NetworkAttachmentDefinition)
+			d := levenshtein(name, netAttachDef.Name)
+			if d == 0 || d > maxDistance {
+				continue
+			}
+			n := netAttachDef.Name
+			if ns != resolvedNamespace {
+				n = ns + "/" + n
+			}
+			candidates = append(candidates, candidate{name: n, distance: d})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < maxNetworkSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// namespacesWithNetwork returns the namespaces other than namespace holding
+// a NAD called name that the pod may use, i.e. its own namespace and the
+// namespaces granted to it or whose NAD RBAC lets one of the users use, so
+// that the NADs of other tenants are not disclosed
+func namespacesWithNetwork(name, namespace, podNamespace string, users []authenticationv1.UserInfo) []string {
+	objs, err := netAttachDefIndexer.ByIndex(netAttachDefNameIndex, name)
+	if err != nil {
+		return nil
+	}
+	var namespaces []string
+	for _, obj := range objs {
+		netAttachDef := obj.(*netv1.NetworkAttachmentDefinition)
+		ns := netAttachDef.Namespace
+		if ns == namespace {
+			continue
+		}
+		if ns != podNamespace && !networkGranted(podNamespace, ns, name) {
+			if allowed, err := networkUseAllowed(users, ns, name); err != nil || !allowed {
+				continue
+			}
+		}
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
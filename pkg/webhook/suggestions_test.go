@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Network suggestions", func() {
+
+	BeforeEach(func() {
+		useNetAttachDefs(
+			newNetAttachDef("default", "macvlan-conf", `{"cniVersion": "0.3.1", "type": "macvlan"}`),
+			newNetAttachDef("default", "macvlan-conf2", `{"cniVersion": "0.3.1", "type": "macvlan"}`),
+			newNetAttachDef("default", "sriov-conf", `{"cniVersion": "0.3.1", "type": "sriov"}`),
+			newNetAttachDef("infra", "storage-net", `{"cniVersion": "0.3.1", "type": "macvlan"}`),
+		)
+	})
+
+	AfterEach(func() {
+		netAttachDefIndexer = nil
+		grantIndexer = nil
+		networkUseReviewer = reviewNetworkUse
+	})
+
+	DescribeTable("edit distance",
+		func(a, b string, distance int) {
+			Expect(levenshtein(a, b)).To(Equal(distance))
+		},
+		Entry("equal", "macvlan", "macvlan", 0),
+		Entry("empty", "", "abc", 3),
+		Entry("transposition", "srivo", "sriov", 2),
+		Entry("insertion", "macvln", "macvlan", 1),
+	)
+
+	It("should rank suggestions by edit distance", func() {
+		Expect(suggestNetworks("macvlan-conf3", "default", []string{"default"})).To(Equal([]string{"macvlan-conf", "macvlan-conf2"}))
+	})
+
+	It("should not suggest unrelated names", func() {
+		Expect(suggestNetworks("bridge", "default", []string{"default"})).To(BeEmpty())
+	})
+
+	It("should describe a misspelled network", func() {
+		Expect(describeMissingNetwork("default", "srivo-conf", "default", nil)).To(Equal("default/srivo-conf (did you mean sriov-conf?)"))
+	})
+
+	It("should point to the namespace of the pod where the network exists", func() {
+		Expect(describeMissingNetwork("infra", "sriov-conf", "default", nil)).To(Equal("infra/sriov-conf (a net-attach-def with this name exists in namespace default, not in infra)"))
+	})
+
+	It("should not disclose namespaces the pod may not use", func() {
+		Expect(describeMissingNetwork("default", "storage-net", "default", nil)).To(Equal("default/storage-net"))
+	})
+
+	It("should point to granted namespaces where the network exists", func() {
+		useGrants(&admissionv1alpha1.NetworkAttachmentGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+			Spec: admissionv1alpha1.NetworkAttachmentGrantSpec{
+				Networks:   []admissionv1alpha1.NetworkReference{{Namespace: "infra", Name: "storage-net"}},
+				Namespaces: []string{"default"},
+			},
+		})
+		Expect(describeMissingNetwork("default", "storage-net", "default", nil)).To(Equal("default/storage-net (a net-attach-def with this name exists in namespace infra, not in default)"))
+	})
+
+	It("should point to namespaces where RBAC lets the user use the network", func() {
+		networkUseReviewer = func(user authenticationv1.UserInfo, namespace, name string) (bool, error) {
+			return user.Username == "alice" && namespace == "infra" && name == "storage-net", nil
+		}
+		Expect(describeMissingNetwork("default", "storage-net", "default", []authenticationv1.UserInfo{{Username: "bob"}})).To(Equal("default/storage-net"))
+		Expect(describeMissingNetwork("default", "storage-net", "default", []authenticationv1.UserInfo{{Username: "alice"}})).To(Equal("default/storage-net (a net-attach-def with this name exists in namespace infra, not in default)"))
+	})
+
+	It("should include hints in the denial", func() {
+		_, _, err := analyzeIsolationAnnotation(newPodAdmissionReview("default", map[string]string{networksAnnotationKey: "macvlan-cnof"}))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("default/macvlan-cnof (did you mean macvlan-conf"))
+	})
+})
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podObject is the metadata and service account of the pod, or of the pod
+// template of a workload, that the isolate webhook checks
+type podObject struct {
+	Meta *metav1.ObjectMeta
+	// ServiceAccountName is the service account the pods run as
+	ServiceAccountName string
+	// Template is true for the pod template of a workload, whose pods do
+	// not exist yet
+	Template bool
+}
+
+// podTemplateKinds maps the kinds of the workloads carrying a pod template to
+// the function extracting it
+var podTemplateKinds = map[metav1.GroupKind]func(raw []byte) (*v1.PodTemplateSpec, error){
+	{Group: "", Kind: "PodTemplate"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj v1.PodTemplate
+		err := json.Unmarshal(raw, &obj)
+		return &obj.Template, err
+	},
+	{Group: "", Kind: "ReplicationController"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj v1.ReplicationController
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, err
+		}
+		if obj.Spec.Template == nil {
+			return &v1.PodTemplateSpec{}, nil
+		}
+		return obj.Spec.Template, nil
+	},
+	{Group: "apps", Kind: "Deployment"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj appsv1.Deployment
+		err := json.Unmarshal(raw, &obj)
+		return &obj.Spec.Template, err
+	},
+	{Group: "apps", Kind: "StatefulSet"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj appsv1.StatefulSet
+		err := json.Unmarshal(raw, &obj)
+		return &obj.Spec.Template, err
+	},
+	{Group: "apps", Kind: "DaemonSet"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj appsv1.DaemonSet
+		err := json.Unmarshal(raw, &obj)
+		return &obj.Spec.Template, err
+	},
+	{Group: "apps", Kind: "ReplicaSet"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj appsv1.ReplicaSet
+		err := json.Unmarshal(raw, &obj)
+		return &obj.Spec.Template, err
+	},
+	{Group: "batch", Kind: "Job"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj batchv1.Job
+		err := json.Unmarshal(raw, &obj)
+		return &obj.Spec.Template, err
+	},
+	{Group: "batch", Kind: "CronJob"}: func(raw []byte) (*v1.PodTemplateSpec, error) {
+		var obj batchv1.CronJob
+		err := json.Unmarshal(raw, &obj)
+		return &obj.Spec.JobTemplate.Spec.Template, err
+	},
+}
+
+// decodePodObject returns the metadata of the pod, or of the pod template of
+// the workload, in raw
+func decodePodObject(kind metav1.GroupVersionKind, raw []byte) (podObject, error) {
+	if kind.Group == "" && kind.Kind == "Pod" {
+		var pod v1.Pod
+		if err := json.Unmarshal(raw, &pod); err != nil {
+			return podObject{}, err
+		}
+		return podObject{Meta: &pod.ObjectMeta, ServiceAccountName: pod.Spec.ServiceAccountName}, nil
+	}
+
+	extract, ok := podTemplateKinds[metav1.GroupKind{Group: kind.Group, Kind: kind.Kind}]
+	if !ok {
+		return podObject{}, errors.Errorf("unsupported kind %s", kind.String())
+	}
+	template, err := extract(raw)
+	if err != nil {
+		return podObject{}, err
+	}
+	return podObject{Meta: &template.ObjectMeta, ServiceAccountName: template.Spec.ServiceAccountName, Template: true}, nil
+}
+
+// networkAnnotationsUnchanged reports whether an UPDATE leaves the networks
+// and default-network annotations of the pod or pod template as they were, in
+// which case they are not checked again so that unrelated changes of a pod or
+// workload are not blocked
+func networkAnnotationsUnchanged(req *admissionv1.AdmissionRequest, obj podObject) bool {
+	if req.Operation != admissionv1.Update || len(req.OldObject.Raw) == 0 {
+		return false
+	}
+	old, err := decodePodObject(req.Kind, req.OldObject.Raw)
+	if err != nil {
+		return false
+	}
+	for _, key := range []string{networksAnnotationKey, defaultNetworkAnnotationKey} {
+		if old.Meta.Annotations[key] != obj.Meta.Annotations[key] {
+			return false
+		}
+	}
+	return true
+}
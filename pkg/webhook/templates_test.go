@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newWorkloadAdmissionReview returns the review of a request creating the
+// workload of the given kind
+func newWorkloadAdmissionReview(kind metav1.GroupVersionKind, workload interface{}) *admissionv1.AdmissionReview {
+	raw, err := json.Marshal(workload)
+	Expect(err).NotTo(HaveOccurred())
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "fake-uid",
+			Kind:      kind,
+			Namespace: "default",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func podTemplate(networks string) v1.PodTemplateSpec {
+	return v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{networksAnnotationKey: networks}},
+	}
+}
+
+func newDeployment(networks string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Template: podTemplate(networks)},
+	}
+}
+
+var deploymentKind = metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+var _ = Describe("Workload pod template checks", func() {
+
+	DescribeTable("pod template extraction",
+		func(kind metav1.GroupVersionKind, workload func(networks string) interface{}) {
+			allowed, _, err := analyzeIsolationAnnotation(newWorkloadAdmissionReview(kind, workload("macvlan-conf")))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+
+			allowed, _, err = analyzeIsolationAnnotation(newWorkloadAdmissionReview(kind, workload("infra/storage-net")))
+			Expect(allowed).To(BeFalse())
+			Expect(err).To(MatchError(ContainSubstring("attachment 1 (infra/storage-net)")))
+		},
+		Entry("Deployment", deploymentKind, func(networks string) interface{} {
+			return newDeployment(networks)
+		}),
+		Entry("StatefulSet", metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, func(networks string) interface{} {
+			return &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Template: podTemplate(networks)}}
+		}),
+		Entry("DaemonSet", metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"}, func(networks string) interface{} {
+			return &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{Template: podTemplate(networks)}}
+		}),
+		Entry("ReplicaSet", metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"}, func(networks string) interface{} {
+			return &appsv1.ReplicaSet{Spec: appsv1.ReplicaSetSpec{Template: podTemplate(networks)}}
+		}),
+		Entry("ReplicationController", metav1.GroupVersionKind{Version: "v1", Kind: "ReplicationController"}, func(networks string) interface{} {
+			template := podTemplate(networks)
+			return &v1.ReplicationController{Spec: v1.ReplicationControllerSpec{Template: &template}}
+		}),
+		Entry("Job", metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, func(networks string) interface{} {
+			return &batchv1.Job{Spec: batchv1.JobSpec{Template: podTemplate(networks)}}
+		}),
+		Entry("CronJob", metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"}, func(networks string) interface{} {
+			return &batchv1.CronJob{Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: podTemplate(networks)}}}}
+		}),
+		Entry("PodTemplate", metav1.GroupVersionKind{Version: "v1", Kind: "PodTemplate"}, func(networks string) interface{} {
+			return &v1.PodTemplate{Template: podTemplate(networks)}
+		}),
+	)
+
+	It("should admit a workload without pod template", func() {
+		allowed, _, err := analyzeIsolationAnnotation(newWorkloadAdmissionReview(
+			metav1.GroupVersionKind{Version: "v1", Kind: "ReplicationController"}, &v1.ReplicationController{}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should deny unsupported kinds", func() {
+		allowed, _, err := analyzeIsolationAnnotation(newWorkloadAdmissionReview(
+			metav1.GroupVersionKind{Version: "v1", Kind: "Service"}, &v1.Service{}))
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("unsupported kind")))
+	})
+
+	It("should only check updates changing the networks annotation", func() {
+		oldRaw, err := json.Marshal(newDeployment("infra/storage-net"))
+		Expect(err).NotTo(HaveOccurred())
+
+		ar := newWorkloadAdmissionReview(deploymentKind, newDeployment("infra/storage-net"))
+		ar.Request.Operation = admissionv1.Update
+		ar.Request.OldObject = runtime.RawExtension{Raw: oldRaw}
+		allowed, _, err := analyzeIsolationAnnotation(ar)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+
+		ar = newWorkloadAdmissionReview(deploymentKind, newDeployment("macvlan-conf,infra/storage-net"))
+		ar.Request.Operation = admissionv1.Update
+		ar.Request.OldObject = runtime.RawExtension{Raw: oldRaw}
+		allowed, _, err = analyzeIsolationAnnotation(ar)
+		Expect(allowed).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("attachment 2 (infra/storage-net)")))
+	})
+})
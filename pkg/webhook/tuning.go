@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// interfaceNamePlaceholder stands for the name of the interface the tuning
+// plugin is applied to, in sysctl keys and in the sysctl allow-list
+const interfaceNamePlaceholder = "IFNAME"
+
+// DefaultTuningSysctls are the sysctls the tuning plugin may set: settings of
+// the interface it is applied to that only affect the pod network namespace
+const DefaultTuningSysctls = "net.ipv4.conf.IFNAME.arp_accept,net.ipv4.conf.IFNAME.arp_announce,net.ipv4.conf.IFNAME.arp_filter," +
+	"net.ipv4.conf.IFNAME.arp_ignore,net.ipv4.conf.IFNAME.arp_notify," +
+	"net.ipv4.neigh.IFNAME.base_reachable_time_ms,net.ipv4.neigh.IFNAME.retrans_time_ms," +
+	"net.ipv6.conf.IFNAME.accept_dad,net.ipv6.conf.IFNAME.accept_ra,net.ipv6.conf.IFNAME.autoconf," +
+	"net.ipv6.conf.IFNAME.dad_transmits,net.ipv6.conf.IFNAME.disable_ipv6,net.ipv6.conf.IFNAME.use_tempaddr," +
+	"net.ipv6.neigh.IFNAME.base_reachable_time_ms,net.ipv6.neigh.IFNAME.retrans_time_ms"
+
+// sysctlInterfaceAliases are the interface segments of sysctl keys that do
+// not name an interface but apply to all of them
+var sysctlInterfaceAliases = []string{"all", "default"}
+
+// tuningSysctls are the allowed sysctl keys, split into segments
+var tuningSysctls = mustParseTuningSysctls(DefaultTuningSysctls)
+
+// SetTuningSysctls sets the sysctls the tuning plugin may set, given as a
+// comma separated list of keys in which IFNAME stands for any interface
+func SetTuningSysctls(sysctls string) error {
+	parsed, err := parseTuningSysctls(sysctls)
+	if err != nil {
+		return err
+	}
+	tuningSysctls = parsed
+	return nil
+}
+
+func parseTuningSysctls(sysctls string) ([][]string, error) {
+	var parsed [][]string
+	for _, item := range strings.Split(sysctls, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		segments := splitSysctlKey(item)
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, errors.Errorf("invalid tuning sysctl '%s', must be a dot separated sysctl key", item)
+			}
+		}
+		parsed = append(parsed, segments)
+	}
+	return parsed, nil
+}
+
+func mustParseTuningSysctls(sysctls string) [][]string {
+	parsed, err := parseTuningSysctls(sysctls)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// splitSysctlKey splits a sysctl key, which is either dot or slash
+// separated, into its segments
+func splitSysctlKey(key string) []string {
+	if strings.Contains(key, "/") {
+		return strings.Split(strings.Trim(key, "/"), "/")
+	}
+	return strings.Split(key, ".")
+}
+
+// sysctlSegmentMatches reports whether a segment of a sysctl key matches the
+// segment of an allowed key. The interface name placeholder matches itself
+// and the name of an interface, but not the all and default aliases.
+func sysctlSegmentMatches(allowed, segment string) bool {
+	if allowed != interfaceNamePlaceholder {
+		return allowed == segment
+	}
+	if segment == interfaceNamePlaceholder {
+		return true
+	}
+	return !containsString(sysctlInterfaceAliases, segment) && validateInterfaceName(segment) == nil
+}
+
+// sysctlAllowed reports whether the tuning plugin may set the sysctl key
+func sysctlAllowed(key string) bool {
+	segments := splitSysctlKey(key)
+	for _, allowed := range tuningSysctls {
+		if len(allowed) != len(segments) {
+			continue
+		}
+		matches := true
+		for i := range allowed {
+			if !sysctlSegmentMatches(allowed[i], segments[i]) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// validateTuningConf checks the sysctls of a tuning plugin are allowed
+func validateTuningConf(plugin pluginConf) field.ErrorList {
+	allErrs := field.ErrorList{}
+	raw, ok := plugin.Raw["sysctl"]
+	if !ok {
+		return allErrs
+	}
+	path := plugin.Path.Child("sysctl")
+	sysctls, ok := raw.(map[string]interface{})
+	if !ok {
+		return append(allErrs, field.Invalid(path, raw, "must be an object mapping sysctl keys to values"))
+	}
+
+	keys := make([]string, 0, len(sysctls))
+	for key := range sysctls {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := sysctls[key].(string); !ok {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), sysctls[key], "must be a string"))
+		}
+		if !sysctlAllowed(key) {
+			allErrs = append(allErrs, field.Forbidden(path.Key(key),
+				fmt.Sprintf("sysctl %s is not in the allow-list of the tuning plugin", key)))
+		}
+	}
+	return allErrs
+}
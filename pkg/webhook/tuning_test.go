@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tuning sysctl allow-list", func() {
+
+	AfterEach(func() {
+		tuningSysctls = mustParseTuningSysctls(DefaultTuningSysctls)
+	})
+
+	DescribeTable("tuning plugin sysctls",
+		func(config string, expectedErr string) {
+			errs := validatePluginConfs([]byte(config))
+			if expectedErr == "" {
+				Expect(errs).To(BeEmpty())
+			} else {
+				Expect(errs.ToAggregate()).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("no sysctl", `{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "mtu": 1400}]}`, ""),
+		Entry("interface name placeholder", `{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.conf.IFNAME.arp_notify": "1"}}]}`, ""),
+		Entry("interface name", `{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv6.conf.net1.accept_ra": "0"}}]}`, ""),
+		Entry("slash separated key", `{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net/ipv6/conf/eth1.100/disable_ipv6": "1"}}]}`, ""),
+		Entry("all interfaces",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.conf.all.arp_notify": "1"}}]}`,
+			`spec.config.plugins[1].sysctl[net.ipv4.conf.all.arp_notify]: Forbidden: sysctl net.ipv4.conf.all.arp_notify is not in the allow-list of the tuning plugin`),
+		Entry("default interface settings",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.conf.default.arp_notify": "1"}}]}`,
+			"sysctl net.ipv4.conf.default.arp_notify is not in the allow-list"),
+		Entry("cluster network interface",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.conf.eth0.arp_notify": "1"}}]}`,
+			"sysctl net.ipv4.conf.eth0.arp_notify is not in the allow-list"),
+		Entry("security setting",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.conf.IFNAME.rp_filter": "0"}}]}`,
+			"sysctl net.ipv4.conf.IFNAME.rp_filter is not in the allow-list"),
+		Entry("global sysctl",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.ip_forward": "1"}}]}`,
+			"sysctl net.ipv4.ip_forward is not in the allow-list"),
+		Entry("non string value",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.conf.IFNAME.arp_notify": 1}}]}`,
+			"spec.config.plugins[1].sysctl[net.ipv4.conf.IFNAME.arp_notify]: Invalid value: 1: must be a string"),
+		Entry("every tuning entry of a conflist",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning", "sysctl": {"net.ipv4.conf.IFNAME.arp_notify": "1"}},
+				{"type": "tuning", "sysctl": {"net.ipv4.conf.all.accept_redirects": "1"}}]}`,
+			"spec.config.plugins[2].sysctl[net.ipv4.conf.all.accept_redirects]: Forbidden"),
+		Entry("tuning entry anywhere in a conflist",
+			`{"cniVersion": "0.4.0", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "my-tuning"},
+				{"type": "tuning", "sysctl": {"net.ipv4.conf.all.accept_redirects": "1"}}, {"type": "portmap"}]}`,
+			"spec.config.plugins[2].sysctl[net.ipv4.conf.all.accept_redirects]: Forbidden"),
+		Entry("other plugin types", `{"cniVersion": "0.4.0", "type": "my-plugin", "sysctl": {"net.ipv4.conf.all.arp_notify": "1"}}`, ""),
+	)
+
+	It("should use the configured allow-list", func() {
+		Expect(SetTuningSysctls("net.ipv4.conf.IFNAME.rp_filter, net.core.somaxconn")).To(Succeed())
+		Expect(sysctlAllowed("net.ipv4.conf.IFNAME.rp_filter")).To(BeTrue())
+		Expect(sysctlAllowed("net.ipv4.conf.net1.rp_filter")).To(BeTrue())
+		Expect(sysctlAllowed("net/core/somaxconn")).To(BeTrue())
+		Expect(sysctlAllowed("net.ipv4.conf.IFNAME.arp_notify")).To(BeFalse())
+	})
+
+	It("should reject invalid allow-lists", func() {
+		Expect(SetTuningSysctls("net..ipv4")).To(MatchError(ContainSubstring("invalid tuning sysctl 'net..ipv4'")))
+	})
+})
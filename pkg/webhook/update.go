@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DefaultIdentityFields are the plugin fields, as type.field, that identify
+// the network a NAD attaches to and may not change on UPDATE; nested fields
+// are given as a dotted path, e.g. bridge.ipam.subnet
+const DefaultIdentityFields = "bridge.bridge,bridge.vlan,host-device.device,host-device.hwaddr,host-device.kernelpath,host-device.pciBusID,ipvlan.master,macvlan.master,vlan.master,vlan.vlanId"
+
+// identityFields maps a plugin type to its identity fields
+var identityFields = mustParseIdentityFields(DefaultIdentityFields)
+
+// SetIdentityFields sets the plugin fields that may not change on UPDATE,
+// given as a comma separated list of type.field
+func SetIdentityFields(fields string) error {
+	parsed, err := parseIdentityFields(fields)
+	if err != nil {
+		return err
+	}
+	identityFields = parsed
+	return nil
+}
+
+func parseIdentityFields(fields string) (map[string][]string, error) {
+	parsed := map[string][]string{}
+	for _, item := range strings.Split(fields, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, ".", 2)
+		if len(parts) != 2 || parts[0] == "" || containsString(strings.Split(parts[1], "."), "") {
+			return nil, errors.Errorf("invalid identity field '%s', must be in the form type.field", item)
+		}
+		parsed[parts[0]] = append(parsed[parts[0]], parts[1])
+	}
+	return parsed, nil
+}
+
+func mustParseIdentityFields(fields string) map[string][]string {
+	parsed, err := parseIdentityFields(fields)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// validateIdentityUnchanged denies changing the plugin types of the chain and
+// the identity fields of its plugins
+func validateIdentityUnchanged(oldPlugins, newPlugins []pluginConf) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	oldTypes := make([]string, 0, len(oldPlugins))
+	for _, plugin := range oldPlugins {
+		oldTypes = append(oldTypes, plugin.Type)
+	}
+	newTypes := make([]string, 0, len(newPlugins))
+	for _, plugin := range newPlugins {
+		newTypes = append(newTypes, plugin.Type)
+	}
+	if !reflect.DeepEqual(oldTypes, newTypes) {
+		return append(allErrs, field.Forbidden(field.NewPath("spec", "config"),
+			fmt.Sprintf("plugin types may not change (from %s to %s)", strings.Join(oldTypes, ", "), strings.Join(newTypes, ", "))))
+	}
+
+	for i, plugin := range newPlugins {
+		for _, key := range identityFields[plugin.Type] {
+			keys := strings.Split(key, ".")
+			if !reflect.DeepEqual(lookupField(oldPlugins[i].Raw, keys), lookupField(plugin.Raw, keys)) {
+				allErrs = append(allErrs, field.Forbidden(plugin.Path.Child(keys[0], keys[1:]...),
+					fmt.Sprintf("identifies the network of a %s plugin and may not change", plugin.Type)))
+			}
+		}
+	}
+	return allErrs
+}
+
+// lookupField returns the value at the path of keys into nested objects of a
+// decoded plugin config, nil if some key is missing
+func lookupField(raw map[string]interface{}, keys []string) interface{} {
+	var value interface{} = raw
+	for _, key := range keys {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[key]
+	}
+	return value
+}
+
+// diffConfig appends the paths of the leaves that differ between two decoded
+// JSON values
+func diffConfig(oldValue, newValue interface{}, path *field.Path, changed []string) []string {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := map[string]bool{}
+		for key := range oldMap {
+			keys[key] = true
+		}
+		for key := range newMap {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			changed = diffConfig(oldMap[key], newMap[key], path.Child(key), changed)
+		}
+		return changed
+	}
+
+	oldList, oldIsList := oldValue.([]interface{})
+	newList, newIsList := newValue.([]interface{})
+	if oldIsList && newIsList && len(oldList) == len(newList) {
+		for i := range newList {
+			changed = diffConfig(oldList[i], newList[i], path.Index(i), changed)
+		}
+		return changed
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		changed = append(changed, path.String())
+	}
+	return changed
+}
+
+// checkNetworkUpdate compares the config of an updated NAD with its previous
+// one. Changes to the plugin types or identity fields are denied, any other
+// change is reported as a warning along with the number of running pods
+// referring to the NAD.
+func checkNetworkUpdate(oldNetAttachDef, netAttachDef netv1.NetworkAttachmentDefinition) ([]string, error) {
+	if oldNetAttachDef.Spec.Config == netAttachDef.Spec.Config {
+		return nil, nil
+	}
+
+	root := field.NewPath("spec", "config")
+	var changed []string
+	if oldNetAttachDef.Spec.Config == "" || netAttachDef.Spec.Config == "" {
+		changed = []string{root.String()}
+	} else {
+		oldConf, err := decodeCNIConfig([]byte(oldNetAttachDef.Spec.Config))
+		if err != nil {
+			// nothing to compare a config that never parsed to
+			glog.Infof("skipping update check of %s/%s, previous config is invalid: %v", netAttachDef.Namespace, netAttachDef.Name, err)
+			return nil, nil
+		}
+		newConf, err := decodeCNIConfig([]byte(netAttachDef.Spec.Config))
+		if err != nil {
+			return nil, err
+		}
+		changed = diffConfig(oldConf, newConf, root, nil)
+
+		oldPlugins, oldErr := getPluginConfs([]byte(oldNetAttachDef.Spec.Config))
+		newPlugins, newErr := getPluginConfs([]byte(netAttachDef.Spec.Config))
+		if oldErr == nil && newErr == nil {
+			if errs := validateIdentityUnchanged(oldPlugins, newPlugins); len(errs) > 0 {
+				err := errs.ToAggregate()
+				glog.Info(err)
+				return nil, err
+			}
+		}
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	usage := ""
+	pods, ready, err := podsUsingNetwork(netAttachDef.Namespace, netAttachDef.Name)
+	if err != nil {
+		return nil, err
+	}
+	if ready {
+		if len(pods) == 1 {
+			usage = ", which 1 running pod refers to"
+		} else {
+			usage = fmt.Sprintf(", which %d running pods refer to", len(pods))
+		}
+	}
+	warning := fmt.Sprintf("changed the config of net-attach-def %s/%s%s; running pods keep their current attachment until restarted. Changed fields: %s",
+		netAttachDef.Namespace, netAttachDef.Name, usage, strings.Join(changed, ", "))
+	glog.Info(warning)
+	return []string{warning}, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Network Plumbing Working Group
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Net-attach-def update check", func() {
+
+	const oldConfig = `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "mode": "bridge", "ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}}`
+
+	AfterEach(func() {
+		SetNetworkPodLister(nil)
+		Expect(SetIdentityFields(DefaultIdentityFields)).To(Succeed())
+	})
+
+	DescribeTable("config changes",
+		func(newConfig string, message string, changed string) {
+			usePods(newRunningPod("default", "pod-1", ""), newRunningPod("default", "pod-2", ""))
+			warnings, err := checkNetworkUpdate(*newNetAttachDef("default", "macvlan-conf", oldConfig), *newNetAttachDef("default", "macvlan-conf", newConfig))
+			if message != "" {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			if changed == "" {
+				Expect(warnings).To(BeEmpty())
+				return
+			}
+			Expect(warnings).To(ConsistOf(ContainSubstring("which 2 running pods refer to")))
+			Expect(warnings[0]).To(HaveSuffix("Changed fields: " + changed))
+		},
+		Entry("unchanged", oldConfig, "", ""),
+		Entry("plugin type", `{"cniVersion": "0.3.1", "type": "ipvlan", "master": "eth1", "ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}}`,
+			"spec.config: Forbidden: plugin types may not change (from macvlan to ipvlan)", ""),
+		Entry("plugin chain", `{"cniVersion": "0.3.1", "name": "n", "plugins": [{"type": "macvlan", "master": "eth1"}, {"type": "tuning"}]}`,
+			"plugin types may not change (from macvlan to macvlan, tuning)", ""),
+		Entry("master interface", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth2", "mode": "bridge", "ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}}`,
+			"spec.config.master: Forbidden: identifies the network of a macvlan plugin and may not change", ""),
+		Entry("subnet and mode", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "mode": "private", "ipam": {"type": "host-local", "subnet": "10.1.2.0/24"}}`,
+			"", "spec.config.ipam.subnet, spec.config.mode"),
+		Entry("added field", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "mode": "bridge", "mtu": 1400, "ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}}`,
+			"", "spec.config.mtu"),
+		Entry("reformatted only", `{"ipam":{"subnet":"10.1.1.0/24","type":"host-local"},"master":"eth1","mode":"bridge","type":"macvlan","cniVersion":"0.3.1"}`,
+			"", ""),
+	)
+
+	It("should allow identity changes of fields that are not configured", func() {
+		Expect(SetIdentityFields("vlan.vlanId")).To(Succeed())
+		warnings, err := checkNetworkUpdate(*newNetAttachDef("default", "macvlan-conf", oldConfig),
+			*newNetAttachDef("default", "macvlan-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth2", "mode": "bridge", "ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(HaveSuffix("Changed fields: spec.config.master")))
+	})
+
+	It("should deny changes of nested identity fields", func() {
+		Expect(SetIdentityFields("macvlan.ipam.subnet")).To(Succeed())
+		_, err := checkNetworkUpdate(*newNetAttachDef("default", "macvlan-conf", oldConfig),
+			*newNetAttachDef("default", "macvlan-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "mode": "bridge", "ipam": {"type": "host-local", "subnet": "10.1.2.0/24"}}`))
+		Expect(err).To(MatchError("spec.config.ipam.subnet: Forbidden: identifies the network of a macvlan plugin and may not change"))
+
+		_, err = checkNetworkUpdate(*newNetAttachDef("default", "macvlan-conf", oldConfig),
+			*newNetAttachDef("default", "macvlan-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "mode": "bridge"}`))
+		Expect(err).To(MatchError(ContainSubstring("spec.config.ipam.subnet: Forbidden")))
+	})
+
+	It("should count a single running pod", func() {
+		usePods(newRunningPod("default", "pod-1", ""))
+		warnings, err := checkNetworkUpdate(*newNetAttachDef("default", "macvlan-conf", oldConfig),
+			*newNetAttachDef("default", "macvlan-conf", `{"cniVersion": "0.3.1", "type": "macvlan", "master": "eth1", "mode": "private", "ipam": {"type": "host-local", "subnet": "10.1.1.0/24"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("which 1 running pod refers to")))
+	})
+
+	It("should reject malformed identity fields", func() {
+		Expect(SetIdentityFields("master")).NotTo(Succeed())
+		Expect(SetIdentityFields("bridge.ipam..subnet")).NotTo(Succeed())
+		Expect(SetIdentityFields("bridge.ipam.")).NotTo(Succeed())
+	})
+})
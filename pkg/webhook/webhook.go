@@ -25,11 +25,13 @@ import (
 
 	"github.com/containernetworking/cni/libcni"
 	"github.com/golang/glog"
+	admissionv1alpha1 "github.com/k8snetworkplumbingwg/net-attach-def-admission-controller/pkg/apis/admission/v1alpha1"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
 	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	netattachdefClientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
 	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admission/v1"
-	v1 "k8s.io/api/core/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -85,14 +87,14 @@ func validateCNIConfig(config []byte) error {
 // preprocessCNIConfig process CNI config bytes as following (that multus does too)
 // - if 'name' is missing, 'name' is filled
 func preprocessCNIConfig(name string, config []byte) ([]byte, error) {
-	var c map[string]interface{}
-	if err := json.Unmarshal(config, &c); err != nil {
-		if n, ok := c["name"]; !ok || n == "" {
-			c["name"] = name
-		}
+	c, err := decodeCNIConfig(config)
+	if err != nil {
+		return nil, err
 	}
-	configBytes, err := json.Marshal(c)
-	return configBytes, err
+	if n, ok := c["name"]; !ok || n == "" {
+		c["name"] = name
+	}
+	return encodeCNIConfig(c)
 }
 
 // isJSON detects if a string is in JSON format
@@ -101,23 +103,24 @@ func isJSON(s string) bool {
 	return json.Unmarshal([]byte(s), &js) == nil
 }
 
-func validateNetworkAttachmentDefinition(netAttachDef netv1.NetworkAttachmentDefinition) (bool, error) {
+func validateNetworkAttachmentDefinition(netAttachDef netv1.NetworkAttachmentDefinition, user authenticationv1.UserInfo) (bool, []string, error) {
 	nameRegex := `^[a-z-1-9]([-a-z0-9]*[a-z0-9])?$`
 	isNameCorrect, err := regexp.MatchString(nameRegex, netAttachDef.GetName())
 	if !isNameCorrect {
 		err := errors.New("net-attach-def name is invalid")
 		glog.Info(err)
-		return false, err
+		return false, nil, err
 	}
 	if err != nil {
 		err := errors.New("error validating name")
 		glog.Error(err)
-		return false, err
+		return false, nil, err
 	}
 
 	glog.Infof("validating network config spec: %s", netAttachDef.Spec.Config)
 
 	var confBytes []byte
+	var warnings []string
 	if netAttachDef.Spec.Config != "" {
 		// try to unmarshal config into NetworkConfig or NetworkConfigList
 		//  using actual code from libcni - if succesful, it means that the config
@@ -125,17 +128,17 @@ func validateNetworkAttachmentDefinition(netAttachDef netv1.NetworkAttachmentDef
 		if !isJSON(netAttachDef.Spec.Config) {
 			err := errors.New("configuration string is not in JSON format")
 			glog.Info(err)
-			return false, err
+			return false, nil, err
 		}
 
 		confBytes, err = preprocessCNIConfig(netAttachDef.GetName(), []byte(netAttachDef.Spec.Config))
 		if err != nil {
 			err := errors.New("invalid json")
-			return false, err
+			return false, nil, err
 		}
 		if err := validateCNIConfig(confBytes); err != nil {
 			err := errors.New("invalid config")
-			return false, err
+			return false, nil, err
 		}
 		_, err = libcni.ConfListFromBytes(confBytes)
 		if err != nil {
@@ -144,16 +147,62 @@ func validateNetworkAttachmentDefinition(netAttachDef netv1.NetworkAttachmentDef
 			if err != nil {
 				glog.Infof("spec is not a valid network config: %s", confBytes)
 				err := errors.New("invalid config")
-				return false, err
+				return false, nil, err
 			}
 		}
 
+		// plugin types the user may not use at all are denied before the
+		// details of their configuration are checked
+		if errs := validatePluginTypePolicies(confBytes, netAttachDef.GetNamespace(), user); len(errs) > 0 {
+			err := errs.ToAggregate()
+			glog.Infof("spec is not allowed by plugin type policies: %v", err)
+			return false, nil, err
+		}
+
+		if errs := validatePluginConfs(confBytes); len(errs) > 0 {
+			err := errs.ToAggregate()
+			glog.Infof("spec is not a valid plugin configuration: %v", err)
+			return false, nil, err
+		}
+
+		versionWarnings, errs := validateCNIVersions(confBytes)
+		if len(errs) > 0 {
+			err := errs.ToAggregate()
+			glog.Infof("spec does not declare valid CNI versions: %v", err)
+			return false, nil, err
+		}
+		warnings = versionWarnings
+
+		binaryWarnings, err := checkPluginBinaries(confBytes)
+		if err != nil {
+			return false, nil, err
+		}
+		warnings = append(warnings, binaryWarnings...)
+
+		inventoryWarnings, err := checkNodeInventory(confBytes)
+		if err != nil {
+			return false, nil, err
+		}
+		warnings = append(warnings, inventoryWarnings...)
+
+		lintWarnings, errs := lintNetworkAttachmentDefinition(confBytes)
+		if len(errs) > 0 {
+			err := errs.ToAggregate()
+			glog.Infof("spec does not pass lint rules: %v", err)
+			return false, nil, err
+		}
+		warnings = append(warnings, lintWarnings...)
+
 	} else {
+		warnings, err = checkNodeConfigFile(netAttachDef.GetName())
+		if err != nil {
+			return false, nil, err
+		}
 		glog.Infof("Allowing empty spec.config")
 	}
 
 	glog.Infof("AdmissionReview request allowed: Network Attachment Definition '%s' is valid", confBytes)
-	return true, nil
+	return true, warnings, nil
 }
 
 func prepareAdmissionReviewResponse(allowed bool, message string, ar *admissionv1.AdmissionReview) error {
@@ -221,24 +270,36 @@ func deserializeAdmissionReview(body []byte) (*admissionv1.AdmissionReview, erro
 	return ar, err
 }
 
-func analyzeIsolationAnnotation(ar *admissionv1.AdmissionReview) (bool, error) {
-
-	var metadata *metav1.ObjectMeta
-	var pod v1.Pod
+func analyzeIsolationAnnotation(ar *admissionv1.AdmissionReview) (bool, []string, error) {
 
 	req := ar.Request
 
-	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
+	obj, err := decodePodObject(req.Kind, req.Object.Raw)
+	if err != nil {
 		glog.Errorf("Could not unmarshal raw object: %v", err)
-		return false, err
+		return false, nil, err
+	}
+	if err := checkNetworkStatusAnnotations(req, obj); err != nil {
+		glog.Info(err)
+		return false, nil, err
+	}
+	if networkAnnotationsUnchanged(req, obj) {
+		return true, nil, nil
 	}
 
-	metadata = &pod.ObjectMeta
+	metadata := obj.Meta
 	annotations := metadata.GetAnnotations()
 	if annotations == nil {
 		annotations = map[string]string{}
 	}
 
+	podNamespace := req.Namespace
+	if podNamespace == "" {
+		podNamespace = metadata.GetNamespace()
+	}
+
+	users := networkUsers(req.UserInfo, podNamespace, obj.ServiceAccountName)
+	var warnings []string
 	if len(annotations[networksAnnotationKey]) > 0 {
 
 		glog.Infof("Analyzing %s annotation: %s", networksAnnotationKey, annotations[networksAnnotationKey])
@@ -246,23 +307,59 @@ func analyzeIsolationAnnotation(ar *admissionv1.AdmissionReview) (bool, error) {
 		networks, err := parsePodNetworkAnnotation(annotations[networksAnnotationKey], namespaceConstraint)
 		if err != nil {
 			glog.Errorf("Error during parsePodNetworkAnnotation: %v", err)
-			return false, err
+			return false, nil, err
 		}
 
-		for _, item := range networks {
-			fmt.Printf("name: %v", item.Namespace)
-			if item.Namespace != namespaceConstraint {
-				annotationerrorstring := fmt.Sprintf("%s annotations must not refer to namespaced values (must use local namespace, i.e. must not contain a /), rejected: %s (namespace: %s)", networksAnnotationKey, annotations[networksAnnotationKey], item.Namespace)
-				annotationerror := errors.New(annotationerrorstring)
-				return false, annotationerror
-			}
+		if err := validateNetworkNamespaces(networksAnnotationKey, networks, podNamespace, users); err != nil {
+			glog.Info(err)
+			return false, nil, err
+		}
+
+		if err := validateInterfaceRequests(networks, podNamespace); err != nil {
+			glog.Info(err)
+			return false, nil, err
+		}
+
+		if err := validateNetworkSelectionElements(networksAnnotationKey, networks, podNamespace); err != nil {
+			glog.Info(err)
+			return false, nil, err
+		}
+
+		warnings, err = checkNetworksExist(networksAnnotationKey, networks, podNamespace, users)
+		if err != nil {
+			return false, nil, err
+		}
+
+		capabilityWarnings, err := checkCapabilities(networks, podNamespace)
+		if err != nil {
+			return false, nil, err
 		}
+		warnings = append(warnings, capabilityWarnings...)
+
+		podName := metadata.GetName()
+		if obj.Template {
+			podName = ""
+		}
+		staticIPWarnings, err := checkStaticIPs(networks, podNamespace, podName)
+		if err != nil {
+			return false, nil, err
+		}
+		warnings = append(warnings, staticIPWarnings...)
 
 		glog.Infof("Allowed value: %s", annotations[networksAnnotationKey])
 
 	}
 
-	return true, nil
+	if len(annotations[defaultNetworkAnnotationKey]) > 0 {
+		defaultNetworkWarnings, err := analyzeDefaultNetworkAnnotation(annotations[defaultNetworkAnnotationKey], podNamespace, users)
+		if err != nil {
+			glog.Info(err)
+			return false, nil, err
+		}
+		warnings = append(warnings, defaultNetworkWarnings...)
+	}
+
+	return true, warnings, nil
 
 }
 
@@ -275,6 +372,9 @@ func parsePodNetworkAnnotation(podNetworks, defaultNamespace string) ([]*types.N
 	}
 
 	if strings.IndexAny(podNetworks, "[{\"") >= 0 {
+		if err := validateCNIArgs(podNetworks); err != nil {
+			return nil, fmt.Errorf("parsePodNetworkAnnotation: %v", err)
+		}
 		if err := json.Unmarshal([]byte(podNetworks), &networks); err != nil {
 			return nil, fmt.Errorf("parsePodNetworkAnnotation: failed to parse pod Network Attachment Selection Annotation JSON format: %v", err)
 		}
@@ -374,15 +474,14 @@ func writeResponse(w http.ResponseWriter, ar *admissionv1.AdmissionReview) {
 // IsolateHandler Handles namespace isolation validation.
 func IsolateHandler(w http.ResponseWriter, req *http.Request) {
 
-	var allowed bool
-
 	ar, httpStatus, err := readAdmissionReview(req)
 	if err != nil {
 		http.Error(w, err.Error(), httpStatus)
 		return
 	}
 
-	allowed, err = analyzeIsolationAnnotation(ar)
+	allowed, warnings, err := analyzeIsolationAnnotation(ar)
+	allowed, warnings, err = applyMode(RuleSetIsolate, ar.Request.Namespace, allowed, warnings, err)
 	if err != nil {
 		handleValidationError(w, ar, err)
 		return
@@ -394,26 +493,92 @@ func IsolateHandler(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	ar.Response.Warnings = warnings
 	writeResponse(w, ar)
 }
 
-// ValidateHandler handles net-attach-def validation requests
-func ValidateHandler(w http.ResponseWriter, req *http.Request) {
-	// read AdmissionReview from the HTTP request
+// InventoryHandler Handles node network inventory write validation.
+func InventoryHandler(w http.ResponseWriter, req *http.Request) {
+
 	ar, httpStatus, err := readAdmissionReview(req)
 	if err != nil {
 		http.Error(w, err.Error(), httpStatus)
 		return
 	}
 
-	netAttachDef, err := deserializeNetworkAttachmentDefinition(ar)
+	allowed, err := analyzeInventoryWrite(ar)
 	if err != nil {
 		handleValidationError(w, ar, err)
 		return
 	}
 
+	err = prepareAdmissionReviewResponse(allowed, "", ar)
+	if err != nil {
+		glog.Error(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeResponse(w, ar)
+}
+
+// analyzeNetworkAttachmentDefinition validates the net-attach-def of a
+// CREATE, UPDATE or DELETE request
+func analyzeNetworkAttachmentDefinition(ar *admissionv1.AdmissionReview) (bool, []string, error) {
+	if ar.Request.Operation == admissionv1.Delete {
+		netAttachDef, err := deserializeDeletedNetworkAttachmentDefinition(ar)
+		if err != nil {
+			return false, nil, err
+		}
+		if err := checkNetworkDeletion(netAttachDef); err != nil {
+			return false, nil, err
+		}
+		return true, nil, nil
+	}
+
+	netAttachDef, err := deserializeNetworkAttachmentDefinition(ar)
+	if err != nil {
+		return false, nil, err
+	}
+
 	// perform actual object validation
-	allowed, err := validateNetworkAttachmentDefinition(netAttachDef)
+	allowed, warnings, err := validateNetworkAttachmentDefinition(netAttachDef, ar.Request.UserInfo)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// check the NAD against the other NADs of the cluster
+	overlapWarnings, err := checkSubnetOverlap(netAttachDef)
+	if err != nil {
+		return false, nil, err
+	}
+	warnings = append(warnings, overlapWarnings...)
+
+	if ar.Request.Operation == admissionv1.Update {
+		oldNetAttachDef := netv1.NetworkAttachmentDefinition{}
+		if err := json.Unmarshal(ar.Request.OldObject.Raw, &oldNetAttachDef); err != nil {
+			return false, nil, err
+		}
+		updateWarnings, err := checkNetworkUpdate(oldNetAttachDef, netAttachDef)
+		if err != nil {
+			return false, nil, err
+		}
+		warnings = append(warnings, updateWarnings...)
+	}
+
+	return allowed, warnings, nil
+}
+
+// ValidateHandler handles net-attach-def validation requests
+func ValidateHandler(w http.ResponseWriter, req *http.Request) {
+	// read AdmissionReview from the HTTP request
+	ar, httpStatus, err := readAdmissionReview(req)
+	if err != nil {
+		http.Error(w, err.Error(), httpStatus)
+		return
+	}
+
+	allowed, warnings, err := analyzeNetworkAttachmentDefinition(ar)
+	allowed, warnings, err = applyMode(RuleSetValidate, ar.Request.Namespace, allowed, warnings, err)
 	if err != nil {
 		handleValidationError(w, ar, err)
 		return
@@ -426,6 +591,7 @@ func ValidateHandler(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	ar.Response.Warnings = warnings
 	writeResponse(w, ar)
 }
 
@@ -441,4 +607,19 @@ func SetupInClusterClient() {
 	if err != nil {
 		glog.Fatal(err)
 	}
+
+	netAttachDefClient, err = netattachdefClientset.NewForConfig(config)
+	if err != nil {
+		glog.Fatal(err)
+	}
+
+	admissionClient, err := admissionv1alpha1.NewRESTClient(config)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	setupNetAttachDefInformer()
+	setupNamespaceInformer()
+	setupGrantInformer(admissionClient)
+	setupPluginPolicyInformer(admissionClient)
+	setupInventoryInformer(admissionClient)
 }
@@ -25,6 +25,7 @@ import (
 	"net/http/httptest"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -129,7 +130,7 @@ var _ = Describe("Webhook", func() {
 
 	DescribeTable("Network Attachment Definition validation",
 		func(in netv1.NetworkAttachmentDefinition, out bool, shouldFail bool) {
-			actualOut, err := validateNetworkAttachmentDefinition(in)
+			actualOut, _, err := validateNetworkAttachmentDefinition(in, authenticationv1.UserInfo{})
 			Expect(actualOut).To(Equal(out))
 			if shouldFail {
 				Expect(err).To(HaveOccurred())